@@ -10,11 +10,155 @@ ActionInterface
 
     # fields:
         # Name: the name of the action interface, used for debugging only
+        # Id: stable identifier of the action, unique within its world, i.e. "text.pressKey.a"
+        # Category: world-specific group of related actions, i.e. "pressKey"
+        # Description: human-readable explanation of what the action does
+        # World: name of the world providing the action
         # Ready: determine whether it is currently legal to perform this action
-        # Step: perform the action
+        # Diagnose: optional variant of Ready, returns the reason the action is currently illegal, nil if legal
+        # Step: perform the action, return its outcome
+        # Schema: arguments accepted by StepWith, nil for zero-argument actions
+        # StepWith: perform a parameterized action with arguments matching Schema, nil for zero-argument actions
+        # Duration: number of ticks the action takes to complete, 0 for instant actions
+        # Cooldown: number of ticks the action is unavailable after being performed
+        # Shorthand: the action only combines other actions of the same actor, i.e. text.typeChar(a) performs text.pressKey.a
 */
 type ActionInterface struct {
-	Name  string
-	Ready func() bool
-    Step  func()
+	Name        string
+	Id          string
+	Category    string
+	Description string
+	World       string
+	Ready       func() bool
+	Diagnose    func() error
+	Step        func() *Outcome
+	Schema      Schema
+	StepWith    func(args ...any) *Outcome
+	Duration    int
+	Cooldown    int
+	Shorthand   bool
+}
+
+// Why returns the reason the action is currently illegal, nil if it is legal
+// actions without Diagnose report ErrNotReady whenever Ready returns false
+func (a *ActionInterface) Why() error {
+	if a.Diagnose != nil {
+		return a.Diagnose()
+	}
+
+	if a.Ready != nil && !a.Ready() {
+		return ErrNotReady
+	}
+
+	return nil
+}
+
+// Parameterized reports whether the action expects arguments through StepWith
+func (a *ActionInterface) Parameterized() bool {
+	return a.StepWith != nil
+}
+
+/*
+ActionDescription
+
+    # the serializable part of an ActionInterface, i.e. to list the actions of an actor in another process
+*/
+type ActionDescription struct {
+	Name          string
+	Id            string
+	Category      string
+	Description   string
+	World         string
+	Schema        Schema
+	Parameterized bool
+	Duration      int
+	Cooldown      int
+	Shorthand     bool
+}
+
+func (a *ActionInterface) Describe() *ActionDescription {
+	return &ActionDescription{
+		Name:          a.Name,
+		Id:            a.Id,
+		Category:      a.Category,
+		Description:   a.Description,
+		World:         a.World,
+		Schema:        a.Schema,
+		Parameterized: a.Parameterized(),
+		Duration:      a.Duration,
+		Cooldown:      a.Cooldown,
+		Shorthand:     a.Shorthand,
+	}
+}
+
+const InfoLabelOutcome = "[outcome]"
+
+type OutcomeStatus int
+
+const (
+	OutcomeSuccess OutcomeStatus = iota // the action changed the world
+	OutcomeNoop                         // the action was legal but had no effect
+	OutcomeRejected                     // the action was illegal and was not performed
+	OutcomePending                      // the action was started and completes after its Duration
+	OutcomeQueued                       // the action was queued and is resolved with all others at Tick
+)
+
+var outcomeLabels = map[OutcomeStatus]string{
+	OutcomeSuccess:  "[success]",
+	OutcomeNoop:     "[noop]",
+	OutcomeRejected: "[rejected]",
+	OutcomePending:  "[pending]",
+	OutcomeQueued:   "[queued]",
+}
+
+// Label returns the Info label describing the status
+func (s OutcomeStatus) Label() string {
+	return outcomeLabels[s]
+}
+
+/*
+Outcome
+
+    # structured result of performing an action
+    # also reported back to the actor as a Touch on its next Feel
+
+    # fields:
+        # Status: success, no-op, rejected, pending or queued
+        # Reason: human-readable explanation, empty on success
+*/
+type Outcome struct {
+	Status OutcomeStatus
+	Reason string
+}
+
+func Success() *Outcome {
+	return &Outcome{Status: OutcomeSuccess}
+}
+
+func Noop(reason string) *Outcome {
+	return &Outcome{Status: OutcomeNoop, Reason: reason}
+}
+
+func Rejected(reason string) *Outcome {
+	return &Outcome{Status: OutcomeRejected, Reason: reason}
+}
+
+func Pending() *Outcome {
+	return &Outcome{Status: OutcomePending}
+}
+
+func Queued() *Outcome {
+	return &Outcome{Status: OutcomeQueued}
+}
+
+// Touch converts the outcome of the named action into the Touch felt by the actor
+func (o *Outcome) Touch(actorId int, actionName string) *Touch {
+	return &Touch{
+		Id:   actorId,
+		Name: actionName,
+		Info: &Info{
+			Labels: []string{InfoLabelObservable, InfoLabelOutcome, o.Status.Label()},
+			Value:  o.Reason,
+		},
+	}
 }
@@ -0,0 +1,51 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutcomeTouch(t *testing.T) {
+	assert.Equal(t, OutcomeSuccess, Success().Status)
+	assert.Empty(t, Success().Reason)
+
+	o := Rejected("no")
+	tch := o.Touch(5, "act")
+	assert.Equal(t, 5, tch.Id)
+	assert.Equal(t, "act", tch.Name)
+	assert.Equal(t, []string{InfoLabelObservable, InfoLabelOutcome, "[rejected]"}, tch.Info.Labels)
+	assert.Equal(t, "no", tch.Info.Value)
+	assert.Equal(t, "[noop]", Noop("").Status.Label())
+	assert.Equal(t, "[success]", OutcomeSuccess.Label())
+}
+
+func TestActionInterfaceWhy(t *testing.T) {
+	ready := false
+	a := &ActionInterface{Ready: func() bool { return ready }}
+	assert.ErrorIs(t, a.Why(), ErrNotReady)
+	ready = true
+	assert.NoError(t, a.Why())
+
+	a.Diagnose = func() error { return ErrActorNotFound }
+	assert.ErrorIs(t, a.Why(), ErrActorNotFound)
+	assert.NoError(t, (&ActionInterface{}).Why())
+}
+
+func TestActionDescribe(t *testing.T) {
+	a := &ActionInterface{
+		Name:     "typeChar",
+		Id:       "test.typeChar",
+		World:    "test",
+		Schema:   Schema{EnumParam("char", "a")},
+		StepWith: func(args ...any) *Outcome { return Success() },
+		Cooldown: 2,
+	}
+
+	d := a.Describe()
+	assert.Equal(t, "test.typeChar", d.Id)
+	assert.Equal(t, a.Schema, d.Schema)
+	assert.True(t, d.Parameterized)
+	assert.Equal(t, 2, d.Cooldown)
+	assert.False(t, (&ActionInterface{}).Describe().Parameterized)
+}
@@ -3,26 +3,37 @@ package adaptor
 import world "github.com/sapphire-ai-dev/sapphire-world"
 
 type actor struct {
-	w     *adaptorWorld
-	id    int
-	links map[int]*link // child world id -> link
+	w       *adaptorWorld
+	id      int
+	links   map[int]*link // child world id -> link
+	actions []*world.ActionInterface
 }
 
-func (a *actor) collectActionInterfaces(argsMap map[int][]any) []*world.ActionInterface {
-	var result []*world.ActionInterface
-	for childWorldId, childWorld := range a.w.children {
-		childActorId, childActions := childWorld.NewActor(argsMap[childWorldId]...)
-		result = append(result, childActions...)
-		a.links[childWorldId] = a.newLink(childWorldId, childActorId)
+// collectActionInterfaces creates a counterpart of the actor in each child world, in the given child world id order
+// if a child world fails, the counterparts already created are removed again
+// it is called without holding the adaptor's lock, before the actor is visible to other calls
+func (a *actor) collectActionInterfaces(childWorldIds []int, children []world.SafeWorld, argsMap map[int][]any) error {
+	for i, child := range children {
+		childActorId, childActions, err := child.NewActor(argsMap[childWorldIds[i]]...)
+		if err != nil {
+			for j := 0; j < i; j++ {
+				_ = children[j].RemoveActor(a.links[childWorldIds[j]].childActorId)
+			}
+
+			return err
+		}
+
+		a.actions = append(a.actions, childActions...)
+		a.links[childWorldIds[i]] = a.newLink(childWorldIds[i], childActorId)
 	}
 
-	return result
+	return nil
 }
 
 func (a *actor) look() []*world.Image {
 	var result []*world.Image
-	for childWorldId, childWorld := range a.w.children {
-		result = append(result, childWorld.Look(a.links[childWorldId].childActorId)...)
+	for _, childWorldId := range a.w.childWorldIds() {
+		result = append(result, a.w.children[childWorldId].Look(a.links[childWorldId].childActorId)...)
 	}
 
 	return result
@@ -30,22 +41,19 @@ func (a *actor) look() []*world.Image {
 
 func (a *actor) feel() []*world.Touch {
 	var result []*world.Touch
-	for childWorldId, childWorld := range a.w.children {
-		result = append(result, childWorld.Feel(a.links[childWorldId].childActorId)...)
+	for _, childWorldId := range a.w.childWorldIds() {
+		result = append(result, a.w.children[childWorldId].Feel(a.links[childWorldId].childActorId)...)
 	}
 
 	return result
 }
 
-func (w *adaptorWorld) newActor() int {
-	id := world.NewUnitId()
-	w.actors[id] = &actor{
+func (w *adaptorWorld) newActor() *actor {
+	return &actor{
 		w:     w,
-		id:    id,
+		id:    w.s.NewUnitId(),
 		links: map[int]*link{},
 	}
-
-	return id
 }
 
 type link struct {
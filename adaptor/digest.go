@@ -0,0 +1,35 @@
+package adaptor
+
+import world "github.com/sapphire-ai-dev/sapphire-world"
+
+// Digest hashes the digests of the child worlds in child world id order, then the links of every actor in id order
+func (w *adaptorWorld) Digest() (world.Digest, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	d := world.NewDigester().String("adaptor").Int(w.clock)
+
+	childWorldIds := w.childWorldIds()
+	d.Int(len(childWorldIds))
+	for _, childWorldId := range childWorldIds {
+		digest, err := w.children[childWorldId].Digest()
+		if err != nil {
+			return world.Digest{}, err
+		}
+
+		d.Int(childWorldId).Nested(digest)
+	}
+
+	actorIds := w.actorIds()
+	d.Int(len(actorIds))
+	for _, actorId := range actorIds {
+		a := w.actors[actorId]
+		d.Int(actorId, w.felt[actorId], len(a.links))
+		for _, childWorldId := range childWorldIds {
+			if l, seen := a.links[childWorldId]; seen {
+				d.Int(childWorldId, l.childActorId)
+			}
+		}
+	}
+
+	return d.Sum(), nil
+}
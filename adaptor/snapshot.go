@@ -0,0 +1,146 @@
+package adaptor
+
+import world "github.com/sapphire-ai-dev/sapphire-world"
+
+// adaptorState is the world specific part of an adaptor snapshot, holding a snapshot of every child world
+type adaptorState struct {
+	owner    *adaptorWorld
+	children map[int]*world.Snapshot // child world id -> snapshot of the child
+	actors   map[int]*actorState     // actorId -> links and actions of the actor
+	felt     map[int]int
+}
+
+type actorState struct {
+	links   map[int]int // child world id -> child actor id
+	actions []*world.ActionInterface
+}
+
+// Snapshot saves every child world together with the links of the adaptor's actors, returning the first error of a child
+func (w *adaptorWorld) Snapshot() (*world.Snapshot, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	state := &adaptorState{
+		owner:    w,
+		children: map[int]*world.Snapshot{},
+		actors:   map[int]*actorState{},
+		felt:     map[int]int{},
+	}
+
+	for _, childWorldId := range w.childWorldIds() {
+		snapshot, err := w.children[childWorldId].Snapshot()
+		if err != nil {
+			return nil, err
+		}
+
+		state.children[childWorldId] = snapshot
+	}
+
+	for actorId, a := range w.actors {
+		saved := &actorState{links: map[int]int{}, actions: a.actions}
+		for childWorldId, l := range a.links {
+			saved.links[childWorldId] = l.childActorId
+		}
+
+		state.actors[actorId] = saved
+		state.felt[actorId] = w.felt[actorId]
+	}
+
+	return &world.Snapshot{World: "adaptor", Clock: w.clock, UnitId: w.s.LastUnitId(), State: state}, nil
+}
+
+// Restore restores every child world in child world id order, the children must be the ones the snapshot was taken with
+// child snapshots are validated before any child is restored, if a child still fails the children restored before it are
+// rolled back to the state they had before the call and the error of the failing child is returned
+func (w *adaptorWorld) Restore(snapshot *world.Snapshot) error {
+	if snapshot == nil {
+		return world.ErrInvalidArgs
+	}
+
+	state, ok := snapshot.State.(*adaptorState)
+	if !ok || state.owner != w {
+		return world.ErrSnapshotMismatch
+	}
+
+	w.mu.RLock()
+	childWorldIds := w.childWorldIds()
+	var children []world.SafeWorld
+	for _, childWorldId := range childWorldIds {
+		children = append(children, w.children[childWorldId])
+	}
+
+	w.mu.RUnlock()
+	if len(state.children) != len(children) {
+		return world.ErrSnapshotMismatch
+	}
+
+	for i, childWorldId := range childWorldIds {
+		saved, seen := state.children[childWorldId]
+		if !seen || saved == nil || saved.World != children[i].Name() {
+			return world.ErrSnapshotMismatch
+		}
+	}
+
+	if err := restoreChildren(childWorldIds, children, state.children); err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	before, after := map[int]bool{}, map[int]bool{}
+	for actorId := range w.actors {
+		before[actorId] = true
+	}
+
+	w.actors = map[int]*actor{}
+	w.felt = map[int]int{}
+	for actorId, saved := range state.actors {
+		after[actorId] = true
+		a := &actor{w: w, id: actorId, links: map[int]*link{}, actions: saved.actions}
+		for childWorldId, childActorId := range saved.links {
+			a.links[childWorldId] = a.newLink(childWorldId, childActorId)
+		}
+
+		w.actors[actorId] = a
+		w.felt[actorId] = state.felt[actorId]
+	}
+
+	for actorId := range before {
+		if !after[actorId] {
+			w.cycles.RemoveActor(actorId)
+		}
+	}
+
+	w.clock = snapshot.Clock
+	w.s.SetLastUnitId(snapshot.UnitId)
+	w.mu.Unlock()
+
+	w.lifecycle.EmitRestored(before, after)
+	return nil
+}
+
+// restoreChildren restores the children one by one, rolling the restored ones back when a later child fails
+func restoreChildren(childWorldIds []int, children []world.SafeWorld, snapshots map[int]*world.Snapshot) error {
+	var rollback []*world.Snapshot
+	for i, childWorldId := range childWorldIds {
+		current, err := children[i].Snapshot()
+		if err != nil {
+			rollbackChildren(children, rollback)
+			return err
+		}
+
+		if err = children[i].Restore(snapshots[childWorldId]); err != nil {
+			rollbackChildren(children, rollback)
+			return err
+		}
+
+		rollback = append(rollback, current)
+	}
+
+	return nil
+}
+
+// rollbackChildren restores the first len(rollback) children to the snapshots they had before restoring
+func rollbackChildren(children []world.SafeWorld, rollback []*world.Snapshot) {
+	for i, current := range rollback {
+		_ = children[i].Restore(current)
+	}
+}
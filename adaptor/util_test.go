@@ -17,6 +17,7 @@ var testWorldName = "test"
 
 type testWorld struct {
 	resetCalled      int
+	tickCalled       int
 	newActorCalled   int
 	newActorArgs     []any
 	newActorReturnId int
@@ -28,6 +29,9 @@ type testWorld struct {
 	feelReturnId     int
 	cmdCalled        int
 	cmdArgs          []any
+	removeCalled     int
+	removeActorId    int
+	newActorPanic    error
 }
 
 func (w *testWorld) Name() string {
@@ -38,11 +42,17 @@ func (w *testWorld) Reset() {
 	w.resetCalled++
 }
 
-func (w *testWorld) Tick() {}
+func (w *testWorld) Tick() {
+	w.tickCalled++
+}
 
 func (w *testWorld) NewActor(args ...any) (int, []*world.ActionInterface) {
 	w.newActorCalled++
 	w.newActorArgs = args
+	if w.newActorPanic != nil {
+		panic(w.newActorPanic)
+	}
+
 	w.newActorReturnId = rand.Intn(1 << 20)
 	return w.newActorReturnId, nil
 }
@@ -63,6 +73,11 @@ func (w *testWorld) Feel(actorId int) []*world.Touch {
 	return []*world.Touch{{Id: w.feelReturnId}}
 }
 
+func (w *testWorld) RemoveActor(actorId int) {
+	w.removeCalled++
+	w.removeActorId = actorId
+}
+
 func (w *testWorld) Cmd(args ...any) {
 	w.cmdCalled++
 	w.cmdArgs = args
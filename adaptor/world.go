@@ -3,100 +3,267 @@ package adaptor
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
+	"sync"
 
 	world "github.com/sapphire-ai-dev/sapphire-world"
 )
 
 // if the agent ever needs to connect to multiple worlds simultaneously, it can connect to this adaptor
 // which would in turn connect to all required worlds on the agent's behalf
+// the adaptor is safe for concurrent use as long as its child worlds are
 type adaptorWorld struct {
-	actors     map[int]*actor      // actorId -> actor
-	cycleFuncs map[int]func()      // actorId -> cycle function
-	children   map[int]world.World // child world id -> child world
+	mu        sync.RWMutex
+	s         *world.Session
+	actors    map[int]*actor          // actorId -> actor
+	cycles    *world.CycleRegistry    // actorId -> cycle function
+	children  map[int]world.SafeWorld // child world id -> child world
+	lifecycle *world.Lifecycle
+	clock     int
+	felt      map[int]int // actorId -> clock at the previous Feel
 }
 
-func (w *adaptorWorld) registerChild() int {
-	child := world.GetWorld()
+func (w *adaptorWorld) registerChild() (int, error) {
+	child := w.s.GetSafeWorld()
 	if child == nil {
-		panic(errWorldNotFound)
+		return 0, world.ErrWorldNotFound
 	}
 
+	w.mu.Lock()
+	defer w.mu.Unlock()
 	for childWorldId, existingChild := range w.children {
 		if existingChild == child {
-			return childWorldId
+			return childWorldId, nil
 		}
 	}
 
-	childWorldId := world.NewUnitId()
+	childWorldId := w.s.NewUnitId()
 	w.children[childWorldId] = child
-	return childWorldId
+	return childWorldId, nil
 }
 
 func (w *adaptorWorld) Name() string {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
 	var childrenNames []string
-	for _, child := range w.children {
-		childrenNames = append(childrenNames, child.Name())
+	for _, childWorldId := range w.childWorldIds() {
+		childrenNames = append(childrenNames, w.children[childWorldId].Name())
 	}
 	return fmt.Sprintf("adaptor: [%s]", strings.Join(childrenNames, ", "))
 }
 
+// Reset eliminates all actors, emitting ActorRemoved for each of them in id order
 func (w *adaptorWorld) Reset() {
+	w.mu.Lock()
+	removed := w.actorIds()
 	w.actors = map[int]*actor{}
-	w.cycleFuncs = map[int]func(){}
-	w.children = map[int]world.World{}
+	w.cycles.Clear()
+	w.children = map[int]world.SafeWorld{}
+	w.clock = 0
+	w.felt = map[int]int{}
+	w.mu.Unlock()
+
+	for _, actorId := range removed {
+		w.lifecycle.Emit(world.ActorRemoved, actorId)
+	}
 }
 
+func (w *adaptorWorld) actorIds() []int {
+	var result []int
+	for actorId := range w.actors {
+		result = append(result, actorId)
+	}
+
+	sort.Ints(result)
+	return result
+}
+
+// Tick advances every child world in child world id order, then runs the adaptor's own cycle functions
 func (w *adaptorWorld) Tick() {
-	for _, f := range w.cycleFuncs {
-		f()
+	w.mu.Lock()
+	w.clock++
+	var children []world.SafeWorld
+	for _, childWorldId := range w.childWorldIds() {
+		children = append(children, w.children[childWorldId])
 	}
+
+	cycles := w.cycles
+	w.mu.Unlock()
+
+	for _, child := range children {
+		child.Tick()
+	}
+
+	cycles.Run()
 }
 
-var (
-	errInvalidArgs   = errors.New("invalid args")
-	errActorNotFound = errors.New("actor not found")
-	errWorldNotFound = errors.New("world not found")
-)
+func (w *adaptorWorld) childWorldIds() []int {
+	var result []int
+	for childWorldId := range w.children {
+		result = append(result, childWorldId)
+	}
+
+	sort.Ints(result)
+	return result
+}
 
-func (w *adaptorWorld) NewActor(args ...any) (int, []*world.ActionInterface) {
+func (w *adaptorWorld) NewActor(args ...any) (int, []*world.ActionInterface, error) {
 	if len(args) > 1 {
-		panic(errInvalidArgs)
+		return 0, nil, world.ErrInvalidArgs
 	}
 
 	argsMap, ok := map[int][]any{}, false
 	if len(args) == 1 {
 		if argsMap, ok = args[0].(map[int][]any); !ok {
-			panic(errInvalidArgs)
+			return 0, nil, world.ErrInvalidArgs
 		}
 	}
 
-	actorId := w.newActor()
-	return actorId, w.actors[actorId].collectActionInterfaces(argsMap)
+	// child worlds are called after unlocking, so their lifecycle hooks may call back into the adaptor
+	w.mu.Lock()
+	a := w.newActor()
+	childWorldIds := w.childWorldIds()
+	var children []world.SafeWorld
+	for _, childWorldId := range childWorldIds {
+		children = append(children, w.children[childWorldId])
+	}
+
+	w.mu.Unlock()
+
+	if err := a.collectActionInterfaces(childWorldIds, children, argsMap); err != nil {
+		return 0, nil, err
+	}
+
+	w.mu.Lock()
+	w.actors[a.id] = a
+	w.felt[a.id] = w.clock
+	w.mu.Unlock()
+
+	w.lifecycle.Emit(world.ActorSpawned, a.id)
+	return a.id, a.actions, nil
 }
 
-func (w *adaptorWorld) Register(actorId int, cycle func()) {
+// RemoveActor removes the actor from every child world it is linked to
+// the actor is removed even if a child world fails to remove its counterpart, that failure is returned
+// child worlds are called after unlocking, so their lifecycle hooks may call back into the adaptor
+func (w *adaptorWorld) RemoveActor(actorId int) error {
+	w.mu.Lock()
+	a, seen := w.actors[actorId]
+	if !seen {
+		w.mu.Unlock()
+		return world.ErrActorNotFound
+	}
+
+	var children []world.SafeWorld
+	var childActorIds []int
+	for _, childWorldId := range w.childWorldIds() {
+		if l, linked := a.links[childWorldId]; linked {
+			children = append(children, w.children[childWorldId])
+			childActorIds = append(childActorIds, l.childActorId)
+		}
+	}
+
+	delete(w.actors, actorId)
+	delete(w.felt, actorId)
+	w.cycles.RemoveActor(actorId)
+	w.mu.Unlock()
+
+	var result error
+	for i, child := range children {
+		if err := child.RemoveActor(childActorIds[i]); err != nil && !errors.Is(err, world.ErrUnsupported) && result == nil {
+			result = err
+		}
+	}
+
+	w.lifecycle.Emit(world.ActorRemoved, actorId)
+	return result
+}
+
+func (w *adaptorWorld) Lifecycle() *world.Lifecycle {
+	return w.lifecycle
+}
+
+func (w *adaptorWorld) Actions(actorId int) ([]*world.ActionInterface, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	a, seen := w.actors[actorId]
+	if !seen {
+		return nil, world.ErrActorNotFound
+	}
+
+	return a.actions, nil
+}
+
+func (w *adaptorWorld) Register(actorId int, cycle func(), opts ...world.CycleOption) (*world.CycleHandle, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
 	if _, seen := w.actors[actorId]; !seen {
-		panic(errActorNotFound)
+		return nil, world.ErrActorNotFound
 	}
 
-	w.cycleFuncs[actorId] = cycle
+	return w.cycles.Register(actorId, cycle, opts...)
 }
 
+// Vocabulary merges the vocabularies of the child worlds in child world id order
+// a family conflicting with one of an earlier child keeps the earlier declaration
+func (w *adaptorWorld) Vocabulary() *world.Vocabulary {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	result := world.NewVocabulary("adaptor")
+	for _, childWorldId := range w.childWorldIds() {
+		if provider, ok := w.children[childWorldId].(world.VocabularyProvider); ok {
+			_ = result.Merge(provider.Vocabulary())
+		}
+	}
+
+	return result
+}
+
+func (w *adaptorWorld) Clock() int {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.clock
+}
+
+// Look collects the images of every child world, stamped with the adaptor's clock
 func (w *adaptorWorld) Look(actorId int) []*world.Image {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
 	if _, seen := w.actors[actorId]; !seen {
 		return []*world.Image{}
 	}
 
-	return w.actors[actorId].look()
+	result := w.actors[actorId].look()
+	for _, img := range result {
+		img.Time = w.clock
+	}
+
+	return result
 }
 
+// Feel collects the touches of every child world, the clock touches of the children are replaced by the adaptor's own
 func (w *adaptorWorld) Feel(actorId int) []*world.Touch {
+	w.mu.Lock()
+	defer w.mu.Unlock()
 	if _, seen := w.actors[actorId]; !seen {
 		return []*world.Touch{}
 	}
 
-	return w.actors[actorId].feel()
+	result := []*world.Touch{}
+	for _, touch := range w.actors[actorId].feel() {
+		if !world.IsClockTouch(touch) {
+			touch.Time = w.clock
+			result = append(result, touch)
+		}
+	}
+
+	if w.felt[actorId] < w.clock {
+		result = append(result, world.ClockTouch(actorId, w.clock))
+		w.felt[actorId] = w.clock
+	}
+
+	return result
 }
 
 const (
@@ -104,67 +271,124 @@ const (
 	CmdTypeChild
 )
 
-func (w *adaptorWorld) Cmd(args ...any) {
+func (w *adaptorWorld) Cmd(args ...any) error {
 	if len(args) < 1 {
-		panic(errInvalidArgs)
+		return world.ErrInvalidArgs
 	}
 
 	cmdType, cmdTypeOk := args[0].(int)
 	if !cmdTypeOk {
-		panic(errInvalidArgs)
+		return world.ErrInvalidArgs
 	}
 
 	if cmdType == CmdTypeLocal {
-		w.CmdLocal(args[1:])
+		return w.CmdLocal(args[1:])
 	} else if cmdType == CmdTypeChild {
 		if len(args) < 2 {
-			panic(errInvalidArgs)
+			return world.ErrInvalidArgs
 		}
 
 		worldId, worldIdOk := args[1].(int)
 		if !worldIdOk {
-			panic(errInvalidArgs)
+			return world.ErrInvalidArgs
 		}
 
-		if child, seen := w.children[worldId]; !seen {
-			panic(errWorldNotFound)
-		} else {
-			child.Cmd(args[2:]...)
+		w.mu.RLock()
+		child, seen := w.children[worldId]
+		w.mu.RUnlock()
+		if !seen {
+			return world.ErrWorldNotFound
 		}
-	} else {
-		panic(errInvalidArgs)
+
+		return child.Cmd(args[2:]...)
 	}
-}
 
-func (w *adaptorWorld) CmdLocal(args ...any) {
+	return world.ErrInvalidArgs
+}
 
+func (w *adaptorWorld) CmdLocal(args ...any) error {
+	return nil
 }
 
 func newAdaptorWorld() *adaptorWorld {
-	result := &adaptorWorld{}
+	return newSessionAdaptorWorld(world.DefaultSession())
+}
+
+func newSessionAdaptorWorld(s *world.Session) *adaptorWorld {
+	result := &adaptorWorld{s: s, lifecycle: world.NewLifecycle(), cycles: world.NewCycleRegistry()}
 	result.Reset()
 	return result
 }
 
-var tempSingleton *adaptorWorld
+/*
+Builder
+
+	# builds an adaptor world out of the worlds registered to its session, one child per Proxy call
+	# every InitStartSession call returns its own builder, so adaptors of different sessions never share children
+	# InitComplete registers the adaptor as the world of the session, children may still be proxied afterwards
+*/
+type Builder struct {
+	w *adaptorWorld
+}
+
+// defaultBuilder backs the package level functions, which build the adaptor of the default session
+var defaultBuilder *Builder
 
 func InitStart() {
-    tempSingleton = newAdaptorWorld()
+	defaultBuilder = InitStartSession(world.DefaultSession())
+}
+
+// InitStartSession starts building an adaptor whose children are proxied from the given session
+func InitStartSession(s *world.Session) *Builder {
+	return &Builder{w: newSessionAdaptorWorld(s)}
 }
 
 // Proxy the currently registered world and return the newly created child world id
 func Proxy() int {
-    if tempSingleton == nil {
-        panic(errWorldNotFound)
-    }
+	return defaultBuilder.Proxy()
+}
 
-    return tempSingleton.registerChild()
+func TryProxy() (int, error) {
+	return defaultBuilder.TryProxy()
 }
 
 func InitComplete() {
-    if tempSingleton == nil {
-        panic(errWorldNotFound)
-    }
+	defaultBuilder.InitComplete()
+}
+
+func TryInitComplete() error {
+	return defaultBuilder.TryInitComplete()
+}
+
+// Proxy the world currently registered to the builder's session and return the newly created child world id
+func (b *Builder) Proxy() int {
+	childWorldId, err := b.TryProxy()
+	if err != nil {
+		panic(err)
+	}
+
+	return childWorldId
+}
+
+func (b *Builder) TryProxy() (int, error) {
+	if b == nil {
+		return 0, world.ErrWorldNotFound
+	}
+
+	return b.w.registerChild()
+}
+
+func (b *Builder) InitComplete() {
+	if err := b.TryInitComplete(); err != nil {
+		panic(err)
+	}
+}
+
+func (b *Builder) TryInitComplete() error {
+	if b == nil {
+		return world.ErrWorldNotFound
+	}
 
-    world.SetWorld(tempSingleton)
+	b.w.s.SetSafeWorld(b.w)
+	return nil
 }
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	world "github.com/sapphire-ai-dev/sapphire-world"
+	"github.com/sapphire-ai-dev/sapphire-world/text"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -11,26 +12,52 @@ func TestAdaptorWorldConstructor(t *testing.T) {
 	w := newAdaptorWorld()
 	assertEmptyNotNil(t, w.actors)
 	assertEmptyNotNil(t, w.children)
-	assertEmptyNotNil(t, w.cycleFuncs)
+	assert.Zero(t, w.cycles.Len())
 }
 
 func TestAdaptorWorldNewActor(t *testing.T) {
 	w := newAdaptorWorld()
-	actorId1, _ := w.NewActor()
-	actorId2, _ := w.NewActor()
+	actorId1, _, _ := w.NewActor()
+	actorId2, _, _ := w.NewActor()
 	assert.NotEqual(t, actorId1, actorId2)
 
-	assert.PanicsWithError(t, errInvalidArgs.Error(), func() {
-		w.NewActor(1)
-	})
+	_, _, err := w.NewActor(1)
+	assert.ErrorIs(t, err, world.ErrInvalidArgs)
 
-	assert.PanicsWithError(t, errInvalidArgs.Error(), func() {
-		w.NewActor(map[int][]any{1: {1, 2, 3}}, 1)
-	})
+	_, _, err = w.NewActor(map[int][]any{1: {1, 2, 3}}, 1)
+	assert.ErrorIs(t, err, world.ErrInvalidArgs)
+
+	_, _, err = w.NewActor(map[int][]any{1: {1, 2, 3}})
+	assert.NoError(t, err)
+}
+
+func TestAdaptorWorldNewActorFailure(t *testing.T) {
+	s := world.NewSession()
+	b := InitStartSession(s)
+	tw1, tw2 := &testWorld{}, &testWorld{newActorPanic: world.ErrInvalidArgs}
+	s.SetWorld(tw1)
+	b.Proxy()
+	s.SetWorld(tw2)
+	b.Proxy()
+	b.InitComplete()
 
-	assert.NotPanics(t, func() {
-		w.NewActor(map[int][]any{1: {1, 2, 3}})
+	// the counterpart created in the first child is removed again
+	_, _, err := s.TryNewActor()
+	assert.ErrorIs(t, err, world.ErrInvalidArgs)
+	assert.Equal(t, 1, tw1.removeCalled)
+	assert.Equal(t, tw1.newActorReturnId, tw1.removeActorId)
+	assert.Empty(t, b.w.actors)
+
+	// a child lifecycle hook may call back into the adaptor while the actor is created
+	tw2.newActorPanic = nil
+	clock := -1
+	b.w.children[b.w.childWorldIds()[0]].Lifecycle().Subscribe(func(event world.LifecycleEvent, childActorId int) {
+		clock = s.Clock()
 	})
+
+	_, _, err = s.TryNewActor()
+	assert.NoError(t, err)
+	assert.Zero(t, clock)
 }
 
 func TestTextWorldRegister(t *testing.T) {
@@ -39,33 +66,31 @@ func TestTextWorldRegister(t *testing.T) {
 	cycleFunc := func() {
 		cycleResult++
 	}
-	assert.PanicsWithError(t, errActorNotFound.Error(), func() {
-		w.Register(0, cycleFunc)
-	})
+	_, err := w.Register(0, cycleFunc)
+	assert.ErrorIs(t, err, world.ErrActorNotFound)
 
 	w.Tick()
 	assert.Equal(t, cycleResult, 0)
 
-	actorId, _ := w.NewActor()
-	assert.NotPanics(t, func() {
-		w.Register(actorId, cycleFunc)
-	})
+	actorId, _, _ := w.NewActor()
+	_, err = w.Register(actorId, cycleFunc)
+	assert.NoError(t, err)
 
 	w.Tick()
 	assert.Equal(t, cycleResult, 1)
 }
 
 func TestAdaptorWorldInit(t *testing.T) {
-	tempSingleton = nil
-	assert.PanicsWithError(t, errWorldNotFound.Error(), func() {
+	defaultBuilder = nil
+	assert.PanicsWithError(t, world.ErrWorldNotFound.Error(), func() {
 		Proxy()
 	})
-	assert.PanicsWithError(t, errWorldNotFound.Error(), func() {
+	assert.PanicsWithError(t, world.ErrWorldNotFound.Error(), func() {
 		InitComplete()
 	})
 
 	InitStart()
-	assert.PanicsWithError(t, errWorldNotFound.Error(), func() {
+	assert.PanicsWithError(t, world.ErrWorldNotFound.Error(), func() {
 		Proxy()
 	})
 
@@ -73,11 +98,31 @@ func TestAdaptorWorldInit(t *testing.T) {
 	world.SetWorld(tw)
 	testWorldId := Proxy()
 	assert.Equal(t, testWorldId, Proxy())
-	assert.Equal(t, tempSingleton.children[testWorldId], tw)
+	assert.Equal(t, world.Must(defaultBuilder.w.children[testWorldId]), tw)
 	assert.Equal(t, tw, world.GetWorld())
 
 	InitComplete()
-	assert.Equal(t, tempSingleton, world.GetWorld())
+	assert.Equal(t, defaultBuilder.w, world.GetSafeWorld())
+}
+
+func TestAdaptorWorldBuilders(t *testing.T) {
+	s1, s2 := world.NewSession(), world.NewSession()
+	b1, b2 := InitStartSession(s1), InitStartSession(s2)
+	tw1, tw2 := &testWorld{}, &testWorld{}
+	s1.SetWorld(tw1)
+	s2.SetWorld(tw2)
+
+	// building the adaptors side by side keeps the children of every session apart
+	childWorldId1 := b1.Proxy()
+	childWorldId2 := b2.Proxy()
+	b1.InitComplete()
+	b2.InitComplete()
+	assert.Len(t, b1.w.children, 1)
+	assert.Len(t, b2.w.children, 1)
+	assert.Equal(t, tw1, world.Must(b1.w.children[childWorldId1]))
+	assert.Equal(t, tw2, world.Must(b2.w.children[childWorldId2]))
+	assert.Equal(t, b1.w, s1.GetSafeWorld())
+	assert.Equal(t, b2.w, s2.GetSafeWorld())
 }
 
 func TestAdaptorWorldLifecycle(t *testing.T) {
@@ -87,14 +132,14 @@ func TestAdaptorWorldLifecycle(t *testing.T) {
 	testWorldId := Proxy()
 	InitComplete()
 
-	assert.Contains(t, tempSingleton.Name(), "adaptor")
-	assert.Contains(t, tempSingleton.Name(), testWorldName)
+	assert.Contains(t, defaultBuilder.w.Name(), "adaptor")
+	assert.Contains(t, defaultBuilder.w.Name(), testWorldName)
 
 	assert.Zero(t, tw.newActorCalled, 0)
 	newActorArgs := []any{1234, "abcd"}
 	adaptorActorId, _ := world.NewActor(map[int][]any{testWorldId: newActorArgs})
 	assert.Equal(t, tw.newActorCalled, 1)
-	assert.Equal(t, tw.newActorReturnId, tempSingleton.actors[adaptorActorId].links[testWorldId].childActorId)
+	assert.Equal(t, tw.newActorReturnId, defaultBuilder.w.actors[adaptorActorId].links[testWorldId].childActorId)
 	for _, newActorArg := range newActorArgs {
 		assert.Contains(t, tw.newActorArgs, newActorArg)
 	}
@@ -103,14 +148,14 @@ func TestAdaptorWorldLifecycle(t *testing.T) {
 	assert.Zero(t, tw.lookActorId)
 	imgs := world.Look(adaptorActorId)
 	assert.Equal(t, tw.lookCalled, 1)
-	assert.Equal(t, tw.lookActorId, tempSingleton.actors[adaptorActorId].links[testWorldId].childActorId)
+	assert.Equal(t, tw.lookActorId, defaultBuilder.w.actors[adaptorActorId].links[testWorldId].childActorId)
 	assert.Equal(t, imgs[0].Id, tw.lookReturnId)
 
 	assert.Zero(t, tw.feelCalled)
 	assert.Zero(t, tw.feelActorId)
 	tchs := world.Feel(adaptorActorId)
 	assert.Equal(t, tw.feelCalled, 1)
-	assert.Equal(t, tw.feelActorId, tempSingleton.actors[adaptorActorId].links[testWorldId].childActorId)
+	assert.Equal(t, tw.feelActorId, defaultBuilder.w.actors[adaptorActorId].links[testWorldId].childActorId)
 	assert.Equal(t, tchs[0].Id, tw.feelReturnId)
 
 	assert.Empty(t, world.Look(1234))
@@ -124,29 +169,29 @@ func TestAdaptorWorldCmd(t *testing.T) {
 	testWorldId := Proxy()
 	InitComplete()
 
-	assert.PanicsWithError(t, errInvalidArgs.Error(), func() {
+	assert.PanicsWithError(t, world.ErrInvalidArgs.Error(), func() {
 		world.Cmd()
 	})
 
-	assert.PanicsWithError(t, errInvalidArgs.Error(), func() {
+	assert.PanicsWithError(t, world.ErrInvalidArgs.Error(), func() {
 		world.Cmd("1")
 	})
 
-	assert.PanicsWithError(t, errInvalidArgs.Error(), func() {
+	assert.PanicsWithError(t, world.ErrInvalidArgs.Error(), func() {
 		world.Cmd(-1)
 	})
 
 	world.Cmd(CmdTypeLocal)
 
-	assert.PanicsWithError(t, errInvalidArgs.Error(), func() {
+	assert.PanicsWithError(t, world.ErrInvalidArgs.Error(), func() {
 		world.Cmd(CmdTypeChild)
 	})
 
-	assert.PanicsWithError(t, errInvalidArgs.Error(), func() {
+	assert.PanicsWithError(t, world.ErrInvalidArgs.Error(), func() {
 		world.Cmd(CmdTypeChild, "1")
 	})
 
-	assert.PanicsWithError(t, errWorldNotFound.Error(), func() {
+	assert.PanicsWithError(t, world.ErrWorldNotFound.Error(), func() {
 		world.Cmd(CmdTypeChild, testWorldId+1)
 	})
 
@@ -158,3 +203,292 @@ func TestAdaptorWorldCmd(t *testing.T) {
 		assert.Contains(t, tw.cmdArgs, cmdArg)
 	}
 }
+
+func TestAdaptorWorldSession(t *testing.T) {
+	s := world.NewSession()
+	b := InitStartSession(s)
+	tw := &testWorld{}
+	s.SetWorld(tw)
+	testWorldId := b.Proxy()
+	b.InitComplete()
+
+	assert.Equal(t, b.w, s.GetSafeWorld())
+	assert.NotSame(t, b.w, world.GetSafeWorld())
+	adaptorActorId, _ := s.NewActor(map[int][]any{testWorldId: {}})
+	assert.Equal(t, 1, tw.newActorCalled)
+	assert.NotEmpty(t, s.Look(adaptorActorId))
+}
+
+func TestAdaptorWorldRemoveActor(t *testing.T) {
+	s := world.NewSession()
+	b := InitStartSession(s)
+	tw := &testWorld{}
+	s.SetWorld(tw)
+	testWorldId := b.Proxy()
+	b.InitComplete()
+
+	var events []world.LifecycleEvent
+	s.GetLifecycle().Subscribe(func(event world.LifecycleEvent, actorId int) {
+		events = append(events, event)
+	})
+
+	assert.ErrorIs(t, s.TryRemoveActor(0), world.ErrActorNotFound)
+
+	actorId, _ := s.NewActor()
+	childActorId := b.w.actors[actorId].links[testWorldId].childActorId
+	calls := 0
+	s.Register(actorId, func() { calls++ })
+	assert.NoError(t, s.TryRemoveActor(actorId))
+	assert.Equal(t, 1, tw.removeCalled)
+	assert.Equal(t, childActorId, tw.removeActorId)
+	assert.NotContains(t, b.w.actors, actorId)
+	assert.Equal(t, []world.LifecycleEvent{world.ActorSpawned, world.ActorRemoved}, events)
+
+	s.Tick()
+	assert.Zero(t, calls)
+
+	// a child lifecycle hook may call back into the adaptor while the actor is removed
+	actorId, _ = s.NewActor()
+	var listed error
+	b.w.children[testWorldId].Lifecycle().Subscribe(func(event world.LifecycleEvent, childActorId int) {
+		if event == world.ActorRemoved {
+			_, listed = s.TryActions(actorId)
+		}
+	})
+
+	assert.NoError(t, s.TryRemoveActor(actorId))
+	assert.ErrorIs(t, listed, world.ErrActorNotFound)
+}
+
+func TestAdaptorWorldActions(t *testing.T) {
+	s := world.NewSession()
+	b := InitStartSession(s)
+	tw := &testWorld{}
+	s.SetWorld(tw)
+	b.Proxy()
+	b.InitComplete()
+
+	_, err := s.TryActions(0)
+	assert.ErrorIs(t, err, world.ErrActorNotFound)
+
+	actorId, actions := s.NewActor()
+	assert.Equal(t, actions, s.Actions(actorId))
+}
+
+func TestAdaptorWorldTickChildren(t *testing.T) {
+	s := world.NewSession()
+	b := InitStartSession(s)
+	tw1, tw2 := &testWorld{}, &testWorld{}
+	s.SetWorld(tw1)
+	b.Proxy()
+	s.SetWorld(tw2)
+	b.Proxy()
+	b.InitComplete()
+
+	s.Tick()
+	s.Tick()
+	assert.Equal(t, 2, tw1.tickCalled)
+	assert.Equal(t, 2, tw2.tickCalled)
+}
+
+func TestAdaptorWorldRuntime(t *testing.T) {
+	s := world.NewSession()
+	text.InitSession(s)
+	b := InitStartSession(s)
+	_, err := b.TryProxy()
+	assert.NoError(t, err)
+	assert.NoError(t, b.TryInitComplete())
+	w := s.GetSafeWorld()
+	r := world.NewRuntime(w)
+	defer r.Stop()
+
+	const actorCount, ticks = 4, 10
+	steps := make([]int, actorCount)
+	for i := 0; i < actorCount; i++ {
+		actorId, actions, err := w.NewActor()
+		assert.NoError(t, err)
+		i := i
+		assert.NoError(t, r.Spawn(actorId, func() {
+			w.Look(actorId)
+			w.Feel(actorId)
+			for _, action := range actions {
+				action.Ready()
+			}
+			actions[0].Step()
+			steps[i]++
+		}))
+	}
+
+	assert.NoError(t, r.Run(ticks))
+	for _, count := range steps {
+		assert.Equal(t, ticks, count)
+	}
+}
+
+func TestAdaptorWorldClock(t *testing.T) {
+	s := world.NewSession()
+	text.InitSession(s)
+	child := s.GetSafeWorld()
+	b := InitStartSession(s)
+	_, err := b.TryProxy()
+	assert.NoError(t, err)
+	assert.NoError(t, b.TryInitComplete())
+	w := s.GetSafeWorld()
+
+	actorId, _, _ := w.NewActor()
+	assert.Empty(t, w.Feel(actorId))
+	w.Tick()
+	w.Tick()
+	assert.Equal(t, 2, w.Clock())
+	assert.Equal(t, 2, child.Clock())
+
+	// the child world's clock touch is replaced by the adaptor's
+	tchs := w.Feel(actorId)
+	assert.Len(t, tchs, 1)
+	assert.Equal(t, actorId, tchs[0].Id)
+	assert.Equal(t, 2, tchs[0].Info.Value)
+	for _, img := range w.Look(actorId) {
+		assert.Equal(t, 2, img.Time)
+	}
+
+	w.Reset()
+	assert.Zero(t, w.Clock())
+}
+
+func TestAdaptorWorldVocabulary(t *testing.T) {
+	s := world.NewSession()
+	text.InitSession(s)
+	b := InitStartSession(s)
+	_, err := b.TryProxy()
+	assert.NoError(t, err)
+	assert.NoError(t, b.TryInitComplete())
+
+	v := s.GetSafeWorld().(world.VocabularyProvider).Vocabulary()
+	assert.Equal(t, "adaptor", v.World)
+	assert.NoError(t, v.Validate(&world.Info{Labels: []string{world.InfoLabelObservable, "[itemType]", "[file]"}}))
+	assert.NoError(t, v.ValidateTouch(world.ClockTouch(1, 1)))
+}
+
+func TestAdaptorWorldSnapshot(t *testing.T) {
+	s := world.NewSession()
+	text.InitSession(s)
+	child := s.GetSafeWorld()
+	b := InitStartSession(s)
+	_, err := b.TryProxy()
+	assert.NoError(t, err)
+	assert.NoError(t, b.TryInitComplete())
+	w := s.GetSafeWorld()
+
+	actorId, actions, _ := w.NewActor()
+	w.Tick()
+	snapshot, err := w.Snapshot()
+	assert.NoError(t, err)
+	unitId := s.LastUnitId()
+
+	otherId, _, _ := w.NewActor()
+	actions[0].Step()
+	w.Tick()
+	w.Tick()
+	assert.NoError(t, w.Restore(snapshot))
+	assert.Equal(t, 1, w.Clock())
+	assert.Equal(t, 1, child.Clock())
+	assert.Equal(t, unitId, s.LastUnitId())
+	_, err = w.Actions(otherId)
+	assert.ErrorIs(t, err, world.ErrActorNotFound)
+	restored, err := w.Actions(actorId)
+	assert.NoError(t, err)
+	assert.Equal(t, actions, restored)
+	assert.Len(t, w.Feel(actorId), 1)
+
+	// a child that cannot be saved fails the snapshot
+	b = InitStartSession(s)
+	s.SetWorld(&testWorld{})
+	_, err = b.TryProxy()
+	assert.NoError(t, err)
+	assert.NoError(t, b.TryInitComplete())
+	_, err = s.Snapshot()
+	assert.ErrorIs(t, err, world.ErrUnsupported)
+	assert.ErrorIs(t, s.Restore(snapshot), world.ErrSnapshotMismatch)
+}
+
+func TestAdaptorWorldRestoreRollback(t *testing.T) {
+	s := world.NewSession()
+	b := InitStartSession(s)
+	var children []world.SafeWorld
+	for i := 0; i < 2; i++ {
+		text.InitSession(s)
+		children = append(children, s.GetSafeWorld())
+		_, err := b.TryProxy()
+		assert.NoError(t, err)
+	}
+
+	assert.NoError(t, b.TryInitComplete())
+	w := s.GetSafeWorld()
+	snapshot, err := w.Snapshot()
+	assert.NoError(t, err)
+	w.Tick()
+
+	// the second child gets a snapshot of the first, restoring it fails after the first child was restored
+	state := snapshot.State.(*adaptorState)
+	first, err := children[0].Snapshot()
+	assert.NoError(t, err)
+	childWorldIds := w.(*adaptorWorld).childWorldIds()
+	state.children[childWorldIds[1]] = first
+	assert.ErrorIs(t, w.Restore(snapshot), world.ErrSnapshotMismatch)
+	assert.Equal(t, 1, w.Clock())
+	assert.Equal(t, 1, children[0].Clock())
+	assert.Equal(t, 1, children[1].Clock())
+
+	state.children[childWorldIds[1]] = nil
+	assert.ErrorIs(t, w.Restore(snapshot), world.ErrSnapshotMismatch)
+}
+
+func TestAdaptorWorldDeterminism(t *testing.T) {
+	episode := func(seed int64) (world.SafeWorld, error) {
+		s := world.NewSession()
+		b := InitStartSession(s)
+		for i := 0; i < 3; i++ {
+			text.InitSession(s)
+			if _, err := b.TryProxy(); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := b.TryInitComplete(); err != nil {
+			return nil, err
+		}
+
+		w := s.GetSafeWorld()
+		for i := 0; i < 2; i++ {
+			actorId, actions, err := w.NewActor()
+			if err != nil {
+				return nil, err
+			}
+
+			step := int(seed) + i
+			if _, err = w.Register(actorId, func() {
+				step++
+				actions[step%len(actions)].Step()
+				w.Look(actorId)
+				w.Feel(actorId)
+			}); err != nil {
+				return nil, err
+			}
+		}
+
+		return w, nil
+	}
+
+	for seed := int64(0); seed < 5; seed++ {
+		assert.NoError(t, world.CheckDeterminism(seed, 20, episode))
+	}
+
+	s := world.NewSession()
+	b := InitStartSession(s)
+	s.SetWorld(&testWorld{})
+	_, err := b.TryProxy()
+	assert.NoError(t, err)
+	assert.NoError(t, b.TryInitComplete())
+	_, err = s.Digest()
+	assert.ErrorIs(t, err, world.ErrUnsupported)
+}
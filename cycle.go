@@ -0,0 +1,192 @@
+package world
+
+import "sync"
+
+/*
+CycleRegistry
+
+	# ordered collection of cycle functions, shared by all worlds
+	# cycles run by ascending priority, cycles of equal priority run in registration order
+	# an actor may own any number of cycle functions, named cycles are unique per actor
+
+	# fields:
+		# entries: registered cycles, kept sorted in execution order
+		# lastSeq: registration counter used to break priority ties
+
+	# safe for concurrent use, cycle functions run without holding the registry lock
+*/
+type CycleRegistry struct {
+	mu      sync.Mutex
+	entries []*cycleEntry
+	lastSeq int
+}
+
+type cycleEntry struct {
+	actorId   int
+	name      string
+	priority  int
+	seq       int
+	cycle     func()
+	cancelled bool
+}
+
+// CycleOption customizes a cycle function at registration time
+type CycleOption func(e *cycleEntry)
+
+// CyclePriority makes a cycle function run before all cycles of a higher priority, the default priority is 0
+func CyclePriority(priority int) CycleOption {
+	return func(e *cycleEntry) {
+		e.priority = priority
+	}
+}
+
+// CycleName names a cycle function, i.e. "perception" or "planning", an actor cannot own two cycles of the same name
+func CycleName(name string) CycleOption {
+	return func(e *cycleEntry) {
+		e.name = name
+	}
+}
+
+/*
+CycleHandle
+
+	# returned by Register, identifies a single registered cycle function
+
+	# methods:
+		# Cancel: unregisters the cycle function, returns false if it was no longer registered
+		# Active: whether the cycle function is still registered
+*/
+type CycleHandle struct {
+	r     *CycleRegistry
+	entry *cycleEntry
+}
+
+func (h *CycleHandle) ActorId() int {
+	return h.entry.actorId
+}
+
+func (h *CycleHandle) Name() string {
+	return h.entry.name
+}
+
+func (h *CycleHandle) Active() bool {
+	h.r.mu.Lock()
+	defer h.r.mu.Unlock()
+	return !h.entry.cancelled
+}
+
+func (h *CycleHandle) Cancel() bool {
+	return h.r.remove(h.entry)
+}
+
+func NewCycleRegistry() *CycleRegistry {
+	return &CycleRegistry{
+		entries: []*cycleEntry{},
+	}
+}
+
+func (r *CycleRegistry) Register(actorId int, cycle func(), opts ...CycleOption) (*CycleHandle, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	entry := &cycleEntry{
+		actorId: actorId,
+		cycle:   cycle,
+	}
+
+	for _, opt := range opts {
+		opt(entry)
+	}
+
+	if entry.name != "" {
+		for _, existing := range r.entries {
+			if existing.actorId == actorId && existing.name == entry.name {
+				return nil, ErrCycleExists
+			}
+		}
+	}
+
+	r.lastSeq++
+	entry.seq = r.lastSeq
+
+	i := len(r.entries)
+	for i > 0 && r.entries[i-1].priority > entry.priority {
+		i--
+	}
+
+	r.entries = append(r.entries, nil)
+	copy(r.entries[i+1:], r.entries[i:])
+	r.entries[i] = entry
+	return &CycleHandle{r: r, entry: entry}, nil
+}
+
+func (r *CycleRegistry) remove(target *cycleEntry) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, entry := range r.entries {
+		if entry == target {
+			entry.cancelled = true
+			r.entries = append(r.entries[:i], r.entries[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+// RemoveActor unregisters every cycle function owned by the actor and returns how many were removed
+func (r *CycleRegistry) RemoveActor(actorId int) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	kept := r.entries[:0]
+	removed := 0
+	for _, entry := range r.entries {
+		if entry.actorId == actorId {
+			entry.cancelled = true
+			removed++
+			continue
+		}
+
+		kept = append(kept, entry)
+	}
+
+	r.entries = kept
+	return removed
+}
+
+// Run executes every registered cycle once
+// cycles registered while running take effect on the next Run, cycles cancelled while running are skipped
+func (r *CycleRegistry) Run() {
+	r.mu.Lock()
+	entries := make([]*cycleEntry, len(r.entries))
+	copy(entries, r.entries)
+	r.mu.Unlock()
+
+	for _, entry := range entries {
+		if r.active(entry) {
+			entry.cycle()
+		}
+	}
+}
+
+func (r *CycleRegistry) active(entry *cycleEntry) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return !entry.cancelled
+}
+
+// Clear unregisters every cycle function, their handles report them as cancelled
+func (r *CycleRegistry) Clear() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, entry := range r.entries {
+		entry.cancelled = true
+	}
+
+	r.entries = []*cycleEntry{}
+}
+
+func (r *CycleRegistry) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.entries)
+}
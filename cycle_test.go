@@ -0,0 +1,151 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCycleRegistryOrder(t *testing.T) {
+	for run := 0; run < 20; run++ {
+		r := NewCycleRegistry()
+		var order []int
+		for actorId := 100; actorId > 0; actorId-- {
+			actorId := actorId
+			r.Register(actorId, func() {
+				order = append(order, actorId)
+			})
+		}
+
+		r.Run()
+		assert.Len(t, order, 100)
+		for i, actorId := range order {
+			assert.Equal(t, 100-i, actorId)
+		}
+	}
+}
+
+func TestCycleRegistryPriority(t *testing.T) {
+	r := NewCycleRegistry()
+	var order []int
+	record := func(actorId int) func() {
+		return func() {
+			order = append(order, actorId)
+		}
+	}
+
+	r.Register(1, record(1))
+	r.Register(2, record(2), CyclePriority(-1))
+	r.Register(3, record(3), CyclePriority(1))
+	r.Register(4, record(4))
+	r.Register(5, record(5), CyclePriority(-1))
+	r.Run()
+	assert.Equal(t, []int{2, 5, 1, 4, 3}, order)
+}
+
+func TestCycleRegistryMultiple(t *testing.T) {
+	r := NewCycleRegistry()
+	var order []string
+	r.Register(1, func() { order = append(order, "a") })
+	r.Register(2, func() { order = append(order, "b") })
+	r.Register(1, func() { order = append(order, "c") })
+	assert.Equal(t, 3, r.Len())
+
+	r.Run()
+	assert.Equal(t, []string{"a", "b", "c"}, order)
+}
+
+func TestCycleRegistryNamed(t *testing.T) {
+	r := NewCycleRegistry()
+	h, err := r.Register(1, func() {}, CycleName("perception"))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, h.ActorId())
+	assert.Equal(t, "perception", h.Name())
+
+	_, err = r.Register(1, func() {}, CycleName("perception"))
+	assert.ErrorIs(t, err, ErrCycleExists)
+	_, err = r.Register(2, func() {}, CycleName("perception"))
+	assert.NoError(t, err)
+	_, err = r.Register(1, func() {}, CycleName("planning"))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, r.Len())
+
+	assert.True(t, h.Cancel())
+	_, err = r.Register(1, func() {}, CycleName("perception"))
+	assert.NoError(t, err)
+}
+
+func TestCycleHandleCancel(t *testing.T) {
+	r := NewCycleRegistry()
+	calls := 0
+	h, _ := r.Register(1, func() { calls++ })
+	assert.True(t, h.Active())
+
+	r.Run()
+	assert.Equal(t, 1, calls)
+	assert.True(t, h.Cancel())
+	assert.False(t, h.Active())
+	assert.False(t, h.Cancel())
+
+	r.Run()
+	assert.Equal(t, 1, calls)
+	assert.Zero(t, r.Len())
+}
+
+func TestCycleRegistryCancelWhileRunning(t *testing.T) {
+	r := NewCycleRegistry()
+	calls := 0
+	var second *CycleHandle
+	r.Register(1, func() {
+		second.Cancel()
+	})
+	second, _ = r.Register(2, func() { calls++ })
+
+	r.Run()
+	assert.Zero(t, calls)
+}
+
+func TestCycleRegistryRemoveActor(t *testing.T) {
+	r := NewCycleRegistry()
+	var order []int
+	h1, _ := r.Register(1, func() { order = append(order, 1) })
+	r.Register(2, func() { order = append(order, 2) })
+	r.Register(1, func() { order = append(order, 1) }, CycleName("named"))
+
+	assert.Equal(t, 2, r.RemoveActor(1))
+	assert.Zero(t, r.RemoveActor(1))
+	assert.False(t, h1.Active())
+
+	r.Run()
+	assert.Equal(t, []int{2}, order)
+}
+
+func TestCycleRegistryClear(t *testing.T) {
+	r := NewCycleRegistry()
+	calls := 0
+	h1, _ := r.Register(1, func() { calls++ })
+	h2, _ := r.Register(2, func() { calls++ })
+
+	r.Clear()
+	assert.False(t, h1.Active())
+	assert.False(t, h2.Active())
+	assert.False(t, h1.Cancel())
+	assert.Zero(t, r.Len())
+
+	r.Run()
+	assert.Zero(t, calls)
+}
+
+func TestCycleRegistryRegisterWhileRunning(t *testing.T) {
+	r := NewCycleRegistry()
+	calls := 0
+	r.Register(1, func() {
+		calls++
+		r.Register(2, func() { calls++ })
+	})
+
+	r.Run()
+	assert.Equal(t, 1, calls)
+	r.Run()
+	assert.Equal(t, 3, calls)
+}
@@ -0,0 +1,89 @@
+package world
+
+import (
+	"reflect"
+	"sort"
+)
+
+/*
+ImageDelta
+
+	# the change of an actor's observation since its previous observation, images are keyed by Image.Id
+
+	# fields:
+		# Added: images not seen in the previous observation
+		# Removed: images of the previous observation that are gone, as they were last seen
+		# Changed: images whose content differs from the previous observation
+*/
+type ImageDelta struct {
+	Added   []*Image
+	Removed []*Image
+	Changed []*Image
+}
+
+func (d *ImageDelta) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+/*
+DeltaLooker
+
+	# optionally implemented by worlds able to compute observation deltas faster than diffing Look
+	# the first LookDelta of an actor reports every visible image as added
+*/
+type DeltaLooker interface {
+	LookDelta(actorId int) *ImageDelta
+}
+
+/*
+DeltaTracker
+
+	# computes observation deltas of any world by diffing consecutive Look results of each actor
+*/
+type DeltaTracker struct {
+	seen map[int]map[int]*Image
+}
+
+func NewDeltaTracker() *DeltaTracker {
+	return &DeltaTracker{seen: map[int]map[int]*Image{}}
+}
+
+// Diff records images as the actor's latest observation and returns its difference from the previous one
+func (t *DeltaTracker) Diff(actorId int, images []*Image) *ImageDelta {
+	result := &ImageDelta{}
+	prev := t.seen[actorId]
+	curr := map[int]*Image{}
+	for _, img := range images {
+		curr[img.Id] = img
+		if old, seen := prev[img.Id]; !seen {
+			result.Added = append(result.Added, img)
+		} else if !sameImage(old, img) {
+			result.Changed = append(result.Changed, img)
+		}
+	}
+
+	for id, old := range prev {
+		if _, seen := curr[id]; !seen {
+			result.Removed = append(result.Removed, old)
+		}
+	}
+
+	sort.Slice(result.Removed, func(i, j int) bool {
+		return result.Removed[i].Id < result.Removed[j].Id
+	})
+
+	t.seen[actorId] = curr
+	return result
+}
+
+// sameImage compares two images regardless of the time they were observed at
+func sameImage(a, b *Image) bool {
+	aCopy, bCopy := *a, *b
+	aCopy.Time, bCopy.Time = 0, 0
+	return reflect.DeepEqual(aCopy, bCopy)
+}
+
+// RemoveActor forgets the actor's previous observation, its next Diff reports everything as added
+func (t *DeltaTracker) RemoveActor(actorId int) {
+	delete(t.seen, actorId)
+}
@@ -0,0 +1,61 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func deltaTestImage(id int, value any) *Image {
+	return &Image{
+		Id:        id,
+		Transient: []*Info{{Labels: []string{InfoLabelObservable}, Value: value}},
+	}
+}
+
+func TestDeltaTracker(t *testing.T) {
+	tr := NewDeltaTracker()
+	d := tr.Diff(1, []*Image{deltaTestImage(1, 0), deltaTestImage(2, 0)})
+	assert.Len(t, d.Added, 2)
+	assert.Empty(t, d.Removed)
+	assert.Empty(t, d.Changed)
+
+	d = tr.Diff(1, []*Image{deltaTestImage(1, 0), deltaTestImage(2, 0)})
+	assert.True(t, d.Empty())
+
+	d = tr.Diff(1, []*Image{deltaTestImage(3, 0), deltaTestImage(2, 1)})
+	assert.Equal(t, 3, d.Added[0].Id)
+	assert.Equal(t, 1, d.Removed[0].Id)
+	assert.Equal(t, 2, d.Changed[0].Id)
+	assert.Equal(t, 1, d.Changed[0].Transient[0].Value)
+
+	// actors are tracked independently
+	assert.Len(t, tr.Diff(2, []*Image{deltaTestImage(3, 0)}).Added, 1)
+
+	tr.RemoveActor(1)
+	assert.Len(t, tr.Diff(1, []*Image{deltaTestImage(3, 0), deltaTestImage(2, 1)}).Added, 2)
+}
+
+type lookWorld struct {
+	panicWorld
+	images []*Image
+}
+
+func (w *lookWorld) Look(_ int) []*Image {
+	return w.images
+}
+
+func TestSessionLookDelta(t *testing.T) {
+	w := &lookWorld{images: []*Image{deltaTestImage(1, 0)}}
+	s := NewSession()
+	s.SetWorld(w)
+	assert.Len(t, s.LookDelta(1).Added, 1)
+	assert.True(t, s.LookDelta(1).Empty())
+
+	w.images = nil
+	assert.Len(t, s.LookDelta(1).Removed, 1)
+
+	w.images = []*Image{deltaTestImage(1, 0)}
+	s.Reset()
+	assert.Len(t, s.LookDelta(1).Added, 1)
+}
@@ -0,0 +1,75 @@
+package world
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrNondeterministic = errors.New("world is not deterministic")
+
+// Nondeterminism reports the first tick at which two runs of the same episode reached different states
+type Nondeterminism struct {
+	Seed   int64
+	Tick   int
+	First  Digest
+	Second Digest
+}
+
+func (n *Nondeterminism) Error() string {
+	return fmt.Sprintf("%v: seed %d diverged at tick %d, %s != %s", ErrNondeterministic, n.Seed, n.Tick, n.First, n.Second)
+}
+
+func (n *Nondeterminism) Unwrap() error {
+	return ErrNondeterministic
+}
+
+/*
+CheckDeterminism
+
+	# runs the same episode twice and compares the digests of both worlds before the first and after every tick
+	# episode sets up a fresh world for the seed, i.e. a text world in a new Session together with its actors and cycles
+	# the runs happen one after the other, episodes sharing the default session are compared correctly
+	# returns a *Nondeterminism for the first tick whose digests differ, or the first error of episode or Digest
+*/
+func CheckDeterminism(seed int64, ticks int, episode func(seed int64) (SafeWorld, error)) error {
+	first, err := runDigests(seed, ticks, episode)
+	if err != nil {
+		return err
+	}
+
+	second, err := runDigests(seed, ticks, episode)
+	if err != nil {
+		return err
+	}
+
+	for tick := range first {
+		if first[tick] != second[tick] {
+			return &Nondeterminism{Seed: seed, Tick: tick, First: first[tick], Second: second[tick]}
+		}
+	}
+
+	return nil
+}
+
+// runDigests returns the digest of the world before the first tick followed by its digest after every tick
+func runDigests(seed int64, ticks int, episode func(seed int64) (SafeWorld, error)) ([]Digest, error) {
+	w, err := episode(seed)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []Digest
+	for tick := 0; ; tick++ {
+		digest, err := w.Digest()
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, digest)
+		if tick == ticks {
+			return result, nil
+		}
+
+		w.Tick()
+	}
+}
@@ -0,0 +1,84 @@
+package world
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"hash"
+)
+
+// Digest is a canonical hash of the full state of a world, two worlds in the same state have the same digest
+type Digest [sha256.Size]byte
+
+func (d Digest) String() string {
+	return hex.EncodeToString(d[:])
+}
+
+/*
+Digester
+
+	# feeds values into a Digest in a canonical encoding, used by worlds to implement SafeWorld.Digest
+	# the encoding is unambiguous, strings are length prefixed and every value is tagged with its type
+	# state kept in maps must be written in a fixed order, i.e. actors by ascending id
+*/
+type Digester struct {
+	h   hash.Hash
+	buf []byte
+}
+
+func NewDigester() *Digester {
+	return &Digester{h: sha256.New()}
+}
+
+func (d *Digester) Int(values ...int) *Digester {
+	for _, value := range values {
+		d.buf = binary.AppendVarint(d.buf[:0], int64(value))
+		d.h.Write(d.buf)
+	}
+
+	return d
+}
+
+func (d *Digester) Bool(value bool) *Digester {
+	if value {
+		return d.Int(1)
+	}
+
+	return d.Int(0)
+}
+
+func (d *Digester) String(values ...string) *Digester {
+	for _, value := range values {
+		d.Int(len(value))
+		d.h.Write([]byte(value))
+	}
+
+	return d
+}
+
+// Value writes an Info value, values of basic types are written as their type and %v representation
+func (d *Digester) Value(value any) *Digester {
+	return d.String(fmt.Sprintf("%T", value), fmt.Sprintf("%v", value))
+}
+
+func (d *Digester) Touch(touch *Touch) *Digester {
+	d.Int(touch.Id, touch.Time).String(touch.Name)
+	if touch.Info == nil {
+		return d.Bool(false)
+	}
+
+	return d.Bool(true).Int(len(touch.Info.Labels)).String(touch.Info.Labels...).Value(touch.Info.Value)
+}
+
+// Nested writes the digest of a part of the world, i.e. a child world
+func (d *Digester) Nested(digest Digest) *Digester {
+	d.h.Write(digest[:])
+	return d
+}
+
+func (d *Digester) Sum() Digest {
+	var result Digest
+	d.h.Sum(result[:0])
+	return result
+}
@@ -0,0 +1,82 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDigester(t *testing.T) {
+	assert.Equal(t, NewDigester().Int(1, 2).String("ab").Sum(), NewDigester().Int(1).Int(2).String("ab").Sum())
+	assert.NotEqual(t, NewDigester().String("ab", "c").Sum(), NewDigester().String("a", "bc").Sum())
+	assert.NotEqual(t, NewDigester().Value(1).Sum(), NewDigester().Value("1").Sum())
+	assert.NotEqual(t, NewDigester().Touch(ClockTouch(1, 1)).Sum(), NewDigester().Touch(ClockTouch(1, 2)).Sum())
+	assert.Len(t, NewDigester().Sum().String(), 64)
+}
+
+type digestPanicWorld struct {
+	panicWorld
+	state int
+}
+
+func (w *digestPanicWorld) Tick() {
+	if cycle, seen := w.cycles[1]; seen {
+		cycle()
+	}
+}
+
+func (w *digestPanicWorld) Digest() Digest {
+	return NewDigester().Int(w.state).Sum()
+}
+
+func TestRecoverDigest(t *testing.T) {
+	_, err := Recover(&panicWorld{}).Digest()
+	assert.ErrorIs(t, err, ErrUnsupported)
+
+	w := Recover(&digestPanicWorld{})
+	before, err := w.Digest()
+	assert.NoError(t, err)
+	w.Tick()
+	after, _ := w.Digest()
+	assert.NotEqual(t, before, after, "the clock of the shim is part of the digest")
+}
+
+func TestCheckDeterminism(t *testing.T) {
+	deterministic := func(seed int64) (SafeWorld, error) {
+		dw := &digestPanicWorld{state: int(seed)}
+		w := Recover(dw)
+		w.NewActor()
+		w.Register(1, func() { dw.state++ })
+		return w, nil
+	}
+	assert.NoError(t, CheckDeterminism(1, 5, deterministic))
+
+	// the world state depends on how often the episode ran before
+	runs := 0
+	nondeterministic := func(seed int64) (SafeWorld, error) {
+		runs++
+		dw := &digestPanicWorld{}
+		w := Recover(dw)
+		w.NewActor()
+		w.Register(1, func() {
+			if w.Clock() == 3 {
+				dw.state += runs
+			}
+		})
+		return w, nil
+	}
+
+	err := CheckDeterminism(7, 5, nondeterministic)
+	var n *Nondeterminism
+	assert.ErrorAs(t, err, &n)
+	assert.ErrorIs(t, err, ErrNondeterministic)
+	assert.Equal(t, int64(7), n.Seed)
+	assert.Equal(t, 3, n.Tick)
+
+	assert.ErrorIs(t, CheckDeterminism(1, 1, func(int64) (SafeWorld, error) {
+		return nil, ErrInvalidArgs
+	}), ErrInvalidArgs)
+	assert.ErrorIs(t, CheckDeterminism(1, 1, func(int64) (SafeWorld, error) {
+		return Recover(&panicWorld{}), nil
+	}), ErrUnsupported)
+}
@@ -5,28 +5,62 @@ import world "github.com/sapphire-ai-dev/sapphire-world"
 /*
 emptyWorld
 
-	# an empty implementation of world.World
+	# an empty implementation of world.SafeWorld
 	# used in tests where no environment interaction is needed
 	# essentially there to prevent nil-pointer exceptions
 
 	# methods:
-	    # all implementations of world.World
+	    # all implementations of world.SafeWorld
 */
-type emptyWorld struct{}
+type emptyWorld struct {
+	lifecycle *world.Lifecycle
+	clock     int
+}
 
 func (w *emptyWorld) Name() string {
 	return "empty"
 }
 
-func (w *emptyWorld) Reset() {}
+func (w *emptyWorld) Reset() {
+	w.clock = 0
+}
+
+func (w *emptyWorld) Tick() {
+	w.clock++
+}
+
+func (w *emptyWorld) Clock() int {
+	return w.clock
+}
+
+func (w *emptyWorld) Snapshot() (*world.Snapshot, error) {
+	return &world.Snapshot{World: w.Name(), Clock: w.clock, State: w}, nil
+}
+
+func (w *emptyWorld) Restore(snapshot *world.Snapshot) error {
+	if snapshot == nil {
+		return world.ErrInvalidArgs
+	}
 
-func (w *emptyWorld) Tick() {}
+	if snapshot.State != w {
+		return world.ErrSnapshotMismatch
+	}
+
+	w.clock = snapshot.Clock
+	return nil
+}
+
+func (w *emptyWorld) Digest() (world.Digest, error) {
+	return world.NewDigester().String(w.Name()).Int(w.clock).Sum(), nil
+}
 
-func (w *emptyWorld) NewActor(_ ...any) (int, []*world.ActionInterface) {
-	return 0, nil
+func (w *emptyWorld) NewActor(_ ...any) (int, []*world.ActionInterface, error) {
+	return 0, nil, nil
 }
 
-func (w *emptyWorld) Register(_ int, _ func()) {}
+func (w *emptyWorld) Register(actorId int, cycle func(), opts ...world.CycleOption) (*world.CycleHandle, error) {
+	return world.NewCycleRegistry().Register(actorId, cycle, opts...)
+}
 
 func (w *emptyWorld) Look(_ int) []*world.Image {
 	return nil
@@ -36,8 +70,31 @@ func (w *emptyWorld) Feel(_ int) []*world.Touch {
 	return nil
 }
 
-func (w *emptyWorld) Cmd(_ ...any) {}
+func (w *emptyWorld) Cmd(_ ...any) error {
+	return nil
+}
+
+func (w *emptyWorld) RemoveActor(_ int) error {
+	return nil
+}
+
+func (w *emptyWorld) Lifecycle() *world.Lifecycle {
+	return w.lifecycle
+}
+
+func (w *emptyWorld) Actions(_ int) ([]*world.ActionInterface, error) {
+	return nil, nil
+}
+
+func newEmptyWorld() *emptyWorld {
+	return &emptyWorld{lifecycle: world.NewLifecycle()}
+}
 
 func Init() {
-	world.SetWorld(&emptyWorld{})
+	InitSession(world.DefaultSession())
+}
+
+// InitSession installs an empty world into the given session
+func InitSession(s *world.Session) {
+	s.SetSafeWorld(newEmptyWorld())
 }
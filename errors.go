@@ -0,0 +1,33 @@
+package world
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrInvalidArgs      = errors.New("invalid args")
+	ErrActorNotFound    = errors.New("actor not found")
+	ErrWorldNotFound    = errors.New("world not found")
+	ErrCycleExists      = errors.New("cycle already registered")
+	ErrUnsupported      = errors.New("unsupported by world")
+	ErrNotReady         = errors.New("action not ready")
+	ErrActorBusy        = errors.New("actor busy with another action")
+	ErrCooldown         = errors.New("action cooling down")
+	ErrConflict         = errors.New("action lost a conflict with another actor")
+	ErrBudgetExhausted  = errors.New("action budget exhausted for this tick")
+	ErrLabelExists      = errors.New("label already declared")
+	ErrUnknownLabel     = errors.New("label not declared")
+	ErrInvalidLabel     = errors.New("labels do not match their declaration")
+	ErrInvalidValue     = errors.New("info value does not match its declaration")
+	ErrSnapshotMismatch = errors.New("snapshot was taken by a different world")
+)
+
+// converts a recovered panic value into an error, keeping sentinel errors intact for errors.Is
+func panicErr(r any) error {
+	if err, ok := r.(error); ok {
+		return err
+	}
+
+	return fmt.Errorf("world panic: %v", r)
+}
@@ -0,0 +1,254 @@
+// Package gym exposes one actor of a world through the Reset and Step interface expected by reinforcement learning tooling.
+package gym
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	world "github.com/sapphire-ai-dev/sapphire-world"
+)
+
+var (
+	ErrNoEpisode   = errors.New("no episode in progress, Reset first")
+	ErrEpisodeDone = errors.New("episode is done, Reset to start a new one")
+	ErrNoOutcome   = errors.New("action reported no outcome")
+)
+
+/*
+Choice
+
+	# one entry of the discrete action space of an Env
+
+	# fields:
+		# Action: the action interface performed by the choice
+		# Args: arguments passed to StepWith, nil if the choice calls Step
+*/
+type Choice struct {
+	Action *world.ActionInterface
+	Args   []any
+}
+
+func (c *Choice) String() string {
+	if c.Args == nil {
+		return c.Action.Id
+	}
+
+	args := make([]string, 0, len(c.Args))
+	for _, arg := range c.Args {
+		args = append(args, fmt.Sprintf("%v", arg))
+	}
+
+	return fmt.Sprintf("%s(%s)", c.Action.Id, strings.Join(args, ", "))
+}
+
+func (c *Choice) Ready() bool {
+	return c.Action.Ready == nil || c.Action.Ready()
+}
+
+// step performs the choice, an action reporting no outcome, i.e. a replayed step missing from its trace, is rejected
+func (c *Choice) step() *world.Outcome {
+	var result *world.Outcome
+	if c.Args == nil {
+		result = c.Action.Step()
+	} else {
+		result = c.Action.StepWith(c.Args...)
+	}
+
+	if result == nil {
+		return world.Rejected(ErrNoOutcome.Error())
+	}
+
+	return result
+}
+
+/*
+Choices
+
+	# expands the action interfaces of an actor into a discrete action space, keeping their order
+	# actions without arguments become a single choice
+	# parameterized actions taking a single enum or int argument become one choice per admissible value
+	# other parameterized actions cannot be enumerated and are left out
+	# shorthand actions are left out as well, i.e. text.typeChar(a) performs text.pressKey.a, so expanding them would list every transition twice
+*/
+func Choices(actions []*world.ActionInterface) []*Choice {
+	var result []*Choice
+	for _, action := range actions {
+		if !action.Parameterized() {
+			result = append(result, &Choice{Action: action})
+			continue
+		}
+
+		if len(action.Schema) != 1 || action.Shorthand {
+			continue
+		}
+
+		switch param := action.Schema[0]; param.Kind {
+		case world.ParamEnum:
+			for _, value := range param.Values {
+				result = append(result, &Choice{Action: action, Args: []any{value}})
+			}
+		case world.ParamInt:
+			for value := param.Min; value <= param.Max; value++ {
+				result = append(result, &Choice{Action: action, Args: []any{value}})
+			}
+		}
+	}
+
+	return result
+}
+
+// Encoder turns what the actor perceived after a Reset or Step into the observation handed to the agent
+type Encoder func(obs *world.Observation) any
+
+// Raw is the default encoder, handing out the observation itself
+func Raw(obs *world.Observation) any {
+	return obs
+}
+
+/*
+Info
+
+	# additional results of a Step
+
+	# fields:
+		# Tick: the world clock after the step
+		# Outcome: outcome of the chosen action
+		# Status: status of the actor's task after the step, zero if the world has no task
+		# Mask: the action mask for the next step
+		# Final: set by VecEnv when it reset the episode ended by the step, the last observation of that episode
+*/
+type Info struct {
+	Tick    int
+	Outcome *world.Outcome
+	Status  world.TaskStatus
+	Mask    []bool
+	Final   any
+}
+
+// Option customizes an Env at construction time
+type Option func(e *Env)
+
+// WithEncoder replaces the Raw encoder
+func WithEncoder(encode Encoder) Option {
+	return func(e *Env) {
+		e.encode = encode
+	}
+}
+
+// WithActorArgs sets the arguments passed to NewActor when an episode starts
+func WithActorArgs(args ...any) Option {
+	return func(e *Env) {
+		e.args = args
+	}
+}
+
+/*
+Env
+
+	# drives a single actor of a world, one Step per tick
+	# every Reset resets the world and spawns a new actor, whose actions make up the action space
+	# reward and episode end come from the world's task, i.e. a world wrapped by world.Attach, without one rewards are 0 and episodes never end
+	# not safe for concurrent use
+*/
+type Env struct {
+	w       world.SafeWorld
+	task    *world.TaskWorld
+	args    []any
+	encode  Encoder
+	actorId int
+	choices []*Choice
+	started bool
+	done    bool
+}
+
+func New(w world.SafeWorld, opts ...Option) *Env {
+	result := &Env{w: w, encode: Raw}
+	for curr := w; curr != nil; curr = world.Unwrap(curr) {
+		if task, ok := curr.(*world.TaskWorld); ok {
+			result.task = task
+			break
+		}
+	}
+
+	for _, opt := range opts {
+		opt(result)
+	}
+
+	return result
+}
+
+// Reset starts a new episode, returning the first observation of the new actor
+func (e *Env) Reset() (any, error) {
+	e.w.Reset()
+	actorId, actions, err := e.w.NewActor(e.args...)
+	if err != nil {
+		e.started = false
+		return nil, err
+	}
+
+	e.actorId, e.choices = actorId, Choices(actions)
+	e.started, e.done = true, false
+	return e.observe(), nil
+}
+
+// Step performs the chosen action and ticks the world, done reports whether the episode ended
+func (e *Env) Step(action int) (obs any, reward float64, done bool, info *Info, err error) {
+	if !e.started {
+		return nil, 0, false, nil, ErrNoEpisode
+	}
+
+	if e.done {
+		return nil, 0, true, nil, ErrEpisodeDone
+	}
+
+	if action < 0 || action >= len(e.choices) {
+		return nil, 0, false, nil, world.ErrInvalidArgs
+	}
+
+	outcome := e.choices[action].step()
+	e.w.Tick()
+
+	info = &Info{Tick: e.w.Clock(), Outcome: outcome}
+	if e.task != nil {
+		if info.Status, err = e.task.Status(e.actorId); err != nil {
+			return nil, 0, false, nil, err
+		}
+	}
+
+	e.done = info.Status.Done()
+	info.Mask = e.Mask()
+	return e.observe(), info.Status.Reward, e.done, info, nil
+}
+
+func (e *Env) observe() any {
+	return e.encode(&world.Observation{
+		Tick:    e.w.Clock(),
+		ActorId: e.actorId,
+		Images:  e.w.Look(e.actorId),
+		Touches: e.w.Feel(e.actorId),
+	})
+}
+
+// Mask reports for every choice of the action space whether its action is ready
+func (e *Env) Mask() []bool {
+	result := make([]bool, len(e.choices))
+	for i, choice := range e.choices {
+		result[i] = choice.Ready()
+	}
+
+	return result
+}
+
+// Choices returns the action space of the current episode
+func (e *Env) Choices() []*Choice {
+	return e.choices
+}
+
+func (e *Env) ActorId() int {
+	return e.actorId
+}
+
+func (e *Env) World() world.SafeWorld {
+	return e.w
+}
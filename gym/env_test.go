@@ -0,0 +1,134 @@
+package gym
+
+import (
+	"testing"
+
+	world "github.com/sapphire-ai-dev/sapphire-world"
+	"github.com/sapphire-ai-dev/sapphire-world/adaptor"
+	"github.com/sapphire-ai-dev/sapphire-world/text"
+	"github.com/stretchr/testify/assert"
+)
+
+func textWorld() world.SafeWorld {
+	s := world.NewSession()
+	text.InitSession(s)
+	return s.GetSafeWorld()
+}
+
+func choiceIndex(e *Env, name string) int {
+	for i, choice := range e.Choices() {
+		if choice.String() == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func TestChoices(t *testing.T) {
+	step := func() *world.Outcome { return world.Success() }
+	stepWith := func(...any) *world.Outcome { return world.Success() }
+	choices := Choices([]*world.ActionInterface{
+		{Id: "plain", World: "w1", Step: step},
+		{Id: "shorthand", World: "w1", Schema: world.Schema{world.EnumParam("v", "x")}, StepWith: stepWith, Shorthand: true},
+		{Id: "move", World: "w1", Schema: world.Schema{world.EnumParam("dir", "left", "right")}, StepWith: stepWith},
+		{Id: "enum", World: "w2", Schema: world.Schema{world.EnumParam("v", "x", "y")}, StepWith: stepWith},
+		{Id: "int", World: "w2", Schema: world.Schema{world.IntParam("v", 1, 3)}, StepWith: stepWith},
+		{Id: "string", World: "w2", Schema: world.Schema{world.StringParam("v", 4)}, StepWith: stepWith},
+		{Id: "pair", World: "w2", Schema: world.Schema{world.IntParam("a", 0, 1), world.IntParam("b", 0, 1)}, StepWith: stepWith},
+	})
+
+	var names []string
+	for _, choice := range choices {
+		names = append(names, choice.String())
+		assert.True(t, choice.Ready())
+	}
+
+	assert.Equal(t, []string{"plain", "move(left)", "move(right)", "enum(x)", "enum(y)", "int(1)", "int(2)", "int(3)"}, names)
+
+	// an action reporting no outcome is rejected
+	silent := &Choice{Action: &world.ActionInterface{Step: func() *world.Outcome { return nil }}}
+	assert.Equal(t, world.Rejected(ErrNoOutcome.Error()), silent.step())
+}
+
+func TestEnv(t *testing.T) {
+	w, err := world.Attach(textWorld(), text.FileContains("notes", "ab"), world.MaxTicks(10))
+	assert.NoError(t, err)
+	e := New(w)
+	_, _, _, _, err = e.Step(0)
+	assert.ErrorIs(t, err, ErrNoEpisode)
+
+	obs, err := e.Reset()
+	assert.NoError(t, err)
+	assert.Equal(t, e.ActorId(), obs.(*world.Observation).ActorId)
+	assert.NotEmpty(t, obs.(*world.Observation).Images)
+
+	enter, typeA, typeB := choiceIndex(e, "text.changeItem.itemEnter"), choiceIndex(e, "text.pressKey.a"), choiceIndex(e, "text.pressKey.b")
+
+	// text.typeChar duplicates the pressKey actions and is left out
+	actions, _ := w.Actions(e.ActorId())
+	assert.Len(t, e.Choices(), len(actions)-1)
+	assert.Equal(t, -1, choiceIndex(e, "text.typeChar(a)"))
+	assert.True(t, e.Mask()[enter])
+	assert.False(t, e.Mask()[typeA])
+	_, _, _, _, err = e.Step(len(e.Choices()))
+	assert.ErrorIs(t, err, world.ErrInvalidArgs)
+
+	_, reward, done, info, err := e.Step(enter)
+	assert.NoError(t, err)
+	assert.Zero(t, reward)
+	assert.False(t, done)
+	assert.Equal(t, world.OutcomeSuccess, info.Outcome.Status)
+	assert.True(t, info.Mask[typeA])
+
+	e.Step(typeA)
+	obs, reward, done, info, err = e.Step(typeB)
+	assert.NoError(t, err)
+	assert.Equal(t, 1.0, reward)
+	assert.True(t, done)
+	assert.True(t, info.Status.Success)
+	assert.Equal(t, 3, obs.(*world.Observation).Tick)
+
+	_, _, _, _, err = e.Step(typeA)
+	assert.ErrorIs(t, err, ErrEpisodeDone)
+
+	// a new episode starts from an empty file with a new actor
+	first := e.ActorId()
+	_, err = e.Reset()
+	assert.NoError(t, err)
+	assert.NotEqual(t, first, e.ActorId())
+	e.Step(enter)
+	_, _, done, _, _ = e.Step(typeB)
+	assert.False(t, done)
+}
+
+func TestEnvWithoutTask(t *testing.T) {
+	encoded := 0
+	e := New(world.Stream(textWorld()), WithEncoder(func(obs *world.Observation) any {
+		encoded++
+		return len(obs.Images)
+	}))
+
+	obs, err := e.Reset()
+	assert.NoError(t, err)
+	assert.Equal(t, 0, obs)
+	for i := 0; i < 3; i++ {
+		_, reward, done, info, err := e.Step(0)
+		assert.NoError(t, err)
+		assert.Zero(t, reward)
+		assert.False(t, done)
+		assert.Equal(t, world.OutcomeRejected, info.Outcome.Status)
+	}
+
+	assert.Equal(t, 4, encoded)
+
+	// the adaptor only accepts a map of child world arguments
+	s := world.NewSession()
+	b := adaptor.InitStartSession(s)
+	assert.NoError(t, b.TryInitComplete())
+	e = New(s.GetSafeWorld(), WithActorArgs(1))
+	_, err = e.Reset()
+	assert.ErrorIs(t, err, world.ErrInvalidArgs)
+	_, _, _, _, err = e.Step(0)
+	assert.ErrorIs(t, err, ErrNoEpisode)
+}
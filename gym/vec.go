@@ -0,0 +1,128 @@
+package gym
+
+import (
+	"fmt"
+	"sync"
+
+	world "github.com/sapphire-ai-dev/sapphire-world"
+)
+
+// Builder installs a world into a fresh session, i.e. text.InitSession followed by world.Attach, returning the world to drive
+type Builder func(s *world.Session) (world.SafeWorld, error)
+
+/*
+VecEnv
+
+	# steps a batch of independent environments concurrently, one goroutine per environment
+	# every environment drives its own world in its own Session, so copies share neither the world nor the unit id allocator
+	# an environment whose episode ends is reset right away, its Info keeps the last observation of the ended episode in Final
+	# errors are reported for the first failing environment in index order
+*/
+type VecEnv struct {
+	envs []*Env
+}
+
+// NewVec builds n environments, each from a world installed by build into a new Session
+func NewVec(n int, build Builder, opts ...Option) (*VecEnv, error) {
+	if n <= 0 || build == nil {
+		return nil, world.ErrInvalidArgs
+	}
+
+	result := &VecEnv{}
+	for i := 0; i < n; i++ {
+		w, err := build(world.NewSession())
+		if err != nil {
+			return nil, fmt.Errorf("env %d: %w", i, err)
+		}
+
+		result.envs = append(result.envs, New(w, opts...))
+	}
+
+	return result, nil
+}
+
+func (v *VecEnv) Len() int {
+	return len(v.envs)
+}
+
+func (v *VecEnv) Env(i int) *Env {
+	return v.envs[i]
+}
+
+// each runs f for every environment concurrently, returning the first error in index order
+func (v *VecEnv) each(f func(i int, e *Env) error) error {
+	errs := make([]error, len(v.envs))
+	var wg sync.WaitGroup
+	for i, e := range v.envs {
+		wg.Add(1)
+		go func(i int, e *Env) {
+			defer wg.Done()
+			errs[i] = f(i, e)
+		}(i, e)
+	}
+
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			return fmt.Errorf("env %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// Reset starts a new episode in every environment
+func (v *VecEnv) Reset() ([]any, error) {
+	result := make([]any, len(v.envs))
+	err := v.each(func(i int, e *Env) (err error) {
+		result[i], err = e.Reset()
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Step performs actions[i] in environment i, resetting the environments whose episode ended
+func (v *VecEnv) Step(actions []int) (obs []any, rewards []float64, dones []bool, infos []*Info, err error) {
+	if len(actions) != len(v.envs) {
+		return nil, nil, nil, nil, world.ErrInvalidArgs
+	}
+
+	obs, rewards = make([]any, len(v.envs)), make([]float64, len(v.envs))
+	dones, infos = make([]bool, len(v.envs)), make([]*Info, len(v.envs))
+	err = v.each(func(i int, e *Env) error {
+		var stepErr error
+		obs[i], rewards[i], dones[i], infos[i], stepErr = e.Step(actions[i])
+		if stepErr != nil || !dones[i] {
+			return stepErr
+		}
+
+		infos[i].Final = obs[i]
+		if obs[i], stepErr = e.Reset(); stepErr != nil {
+			return stepErr
+		}
+
+		infos[i].Mask = e.Mask()
+		return nil
+	})
+
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	return obs, rewards, dones, infos, nil
+}
+
+// Masks returns the action mask of every environment
+func (v *VecEnv) Masks() [][]bool {
+	result := make([][]bool, len(v.envs))
+	for i, e := range v.envs {
+		result[i] = e.Mask()
+	}
+
+	return result
+}
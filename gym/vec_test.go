@@ -0,0 +1,68 @@
+package gym
+
+import (
+	"errors"
+	"testing"
+
+	world "github.com/sapphire-ai-dev/sapphire-world"
+	"github.com/sapphire-ai-dev/sapphire-world/text"
+	"github.com/stretchr/testify/assert"
+)
+
+func fileContains(s *world.Session) (world.SafeWorld, error) {
+	text.InitSession(s)
+	return world.Attach(s.GetSafeWorld(), text.FileContains("notes", "ab"), world.MaxTicks(10))
+}
+
+func TestNewVec(t *testing.T) {
+	_, err := NewVec(0, fileContains)
+	assert.ErrorIs(t, err, world.ErrInvalidArgs)
+	_, err = NewVec(2, nil)
+	assert.ErrorIs(t, err, world.ErrInvalidArgs)
+
+	failure := errors.New("failure")
+	_, err = NewVec(2, func(s *world.Session) (world.SafeWorld, error) { return nil, failure })
+	assert.ErrorIs(t, err, failure)
+}
+
+func TestVecEnv(t *testing.T) {
+	v, err := NewVec(4, fileContains)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, v.Len())
+	_, _, _, _, err = v.Step([]int{0, 0, 0, 0})
+	assert.ErrorIs(t, err, ErrNoEpisode)
+	_, _, _, _, err = v.Step([]int{0})
+	assert.ErrorIs(t, err, world.ErrInvalidArgs)
+
+	obs, err := v.Reset()
+	assert.NoError(t, err)
+	assert.Len(t, obs, 4)
+
+	// each copy allocates ids from its own session, so all copies hand out the same ids
+	for i := 0; i < v.Len(); i++ {
+		assert.Equal(t, v.Env(0).ActorId(), v.Env(i).ActorId())
+		assert.Equal(t, v.Env(i).ActorId(), obs[i].(*world.Observation).ActorId)
+		assert.Len(t, v.Masks()[i], len(v.Env(i).Choices()))
+	}
+
+	e := v.Env(0)
+	enter, typeA, typeB := choiceIndex(e, "text.changeItem.itemEnter"), choiceIndex(e, "text.pressKey.a"), choiceIndex(e, "text.pressKey.b")
+	v.Step([]int{enter, enter, enter, enter})
+	v.Step([]int{typeA, typeA, typeA, typeB})
+	obs, rewards, dones, infos, err := v.Step([]int{typeB, typeA, typeB, typeB})
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{1, 0, 1, 0}, rewards)
+	assert.Equal(t, []bool{true, false, true, false}, dones)
+
+	// finished episodes were reset, the observation of the ended episode is kept in Final
+	first := v.Env(1).ActorId()
+	for _, i := range []int{0, 2} {
+		assert.Equal(t, 3, infos[i].Final.(*world.Observation).Tick)
+		assert.Equal(t, v.Env(i).ActorId(), obs[i].(*world.Observation).ActorId)
+		assert.NotEqual(t, first, v.Env(i).ActorId())
+		assert.Equal(t, v.Masks()[i], infos[i].Mask)
+	}
+
+	assert.Nil(t, infos[1].Final)
+	assert.Equal(t, first, v.Env(3).ActorId())
+}
@@ -2,6 +2,9 @@ package world
 
 const InfoLabelObservable = "observable"
 
+// InfoLabelClock labels the Info of a clock touch, its Value is the world clock
+const InfoLabelClock = "[clock]"
+
 const TernaryPos = "[pos]"
 const TernaryZro = "[zro]"
 const TernaryNeg = "[neg]"
@@ -31,12 +34,14 @@ Image
         # Id: the unit Id
         # Permanent: collection of permanent information, i.e. appearance
         # Transient: collection of transient information, i.e. location
+        # Time: world clock at which the image was observed
 */
 type Image struct {
     Id        int
     Name      string
     Permanent []*Info
     Transient []*Info
+    Time      int
 }
 
 /*
@@ -47,9 +52,43 @@ Touch
     # fields:
         # Id: the unit Id
         # Info: to store contact information
+        # Time: world clock at which the contact happened
 */
 type Touch struct {
     Id   int
     Name string
     Info *Info
+    Time int
+}
+
+/*
+ClockTouch
+
+    # lets an actor perceive elapsed time
+    # worlds append one to Feel whenever their clock advanced since the actor's previous Feel
+*/
+func ClockTouch(actorId, clock int) *Touch {
+    return &Touch{
+        Id:   actorId,
+        Name: "clock",
+        Info: &Info{
+            Labels: []string{InfoLabelObservable, InfoLabelClock},
+            Value:  clock,
+        },
+        Time: clock,
+    }
+}
+
+func IsClockTouch(touch *Touch) bool {
+    if touch.Info == nil {
+        return false
+    }
+
+    for _, label := range touch.Info.Labels {
+        if label == InfoLabelClock {
+            return true
+        }
+    }
+
+    return false
 }
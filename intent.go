@@ -0,0 +1,190 @@
+package world
+
+import "sort"
+
+/*
+Intent
+
+	# an action an actor asked to perform in simultaneous mode, queued until the world resolves it at Tick
+
+	# fields:
+		# ActorId: the actor that performed the action
+		# ActionId, Name: identify the action interface that was invoked
+		# Args: arguments passed to StepWith, nil for Step
+		# Key: intents with equal keys conflict with each other, i.e. edits to the same line
+		# Seq: queueing order
+*/
+type Intent struct {
+	ActorId  int
+	ActionId string
+	Name     string
+	Args     []any
+	Key      any
+	Seq      int
+	perform  func() *Outcome
+}
+
+/*
+ConflictPolicy
+
+	# decides which intents of a group of conflicting intents are performed, and in which order
+	# intents left out of the returned slice are rejected with ErrConflict
+*/
+type ConflictPolicy interface {
+	Resolve(intents []*Intent) []*Intent
+}
+
+type ConflictPolicyFunc func(intents []*Intent) []*Intent
+
+func (f ConflictPolicyFunc) Resolve(intents []*Intent) []*Intent {
+	return f(intents)
+}
+
+var (
+	// ResolveInOrder performs every intent in queueing order, the first actor to act goes first
+	ResolveInOrder ConflictPolicy = ConflictPolicyFunc(func(intents []*Intent) []*Intent {
+		return intents
+	})
+
+	// ResolveByActorId performs every intent, ordered by actor id then queueing order
+	ResolveByActorId ConflictPolicy = ConflictPolicyFunc(func(intents []*Intent) []*Intent {
+		result := make([]*Intent, len(intents))
+		copy(result, intents)
+		sort.SliceStable(result, func(i, j int) bool {
+			return result[i].ActorId < result[j].ActorId
+		})
+
+		return result
+	})
+
+	// ResolveLowestActorWins performs only the intents of the lowest actor id
+	ResolveLowestActorWins ConflictPolicy = ConflictPolicyFunc(func(intents []*Intent) []*Intent {
+		winner := intents[0].ActorId
+		for _, intent := range intents {
+			if intent.ActorId < winner {
+				winner = intent.ActorId
+			}
+		}
+
+		var result []*Intent
+		for _, intent := range intents {
+			if intent.ActorId == winner {
+				result = append(result, intent)
+			}
+		}
+
+		return result
+	})
+
+	// ResolveRejectContested rejects all intents of a group involving more than one actor
+	ResolveRejectContested ConflictPolicy = ConflictPolicyFunc(func(intents []*Intent) []*Intent {
+		for _, intent := range intents {
+			if intent.ActorId != intents[0].ActorId {
+				return nil
+			}
+		}
+
+		return intents
+	})
+)
+
+/*
+IntentQueue
+
+	# collects intents during a tick and resolves them together, shared by all worlds
+	# groups of conflicting intents are resolved in the order of their first intent
+	# like the Scheduler, it relies on the owning world for locking
+*/
+type IntentQueue struct {
+	policy  ConflictPolicy
+	intents []*Intent
+	lastSeq int
+}
+
+func NewIntentQueue(policy ConflictPolicy) *IntentQueue {
+	return &IntentQueue{policy: policy}
+}
+
+func (q *IntentQueue) SetPolicy(policy ConflictPolicy) {
+	q.policy = policy
+}
+
+func (q *IntentQueue) Policy() ConflictPolicy {
+	return q.policy
+}
+
+// Enqueue queues perform as an intent, perform is invoked by Resolve if the policy lets the intent through
+func (q *IntentQueue) Enqueue(actorId int, actionId, name string, args []any, key any, perform func() *Outcome) *Outcome {
+	q.lastSeq++
+	q.intents = append(q.intents, &Intent{
+		ActorId:  actorId,
+		ActionId: actionId,
+		Name:     name,
+		Args:     args,
+		Key:      key,
+		Seq:      q.lastSeq,
+		perform:  perform,
+	})
+
+	return Queued()
+}
+
+// Resolve performs or rejects every queued intent, reject receives the intents left out by the policy
+func (q *IntentQueue) Resolve(reject func(intent *Intent, outcome *Outcome)) {
+	intents := q.intents
+	q.intents = nil
+
+	var keys []any
+	groups := map[any][]*Intent{}
+	for _, intent := range intents {
+		if _, seen := groups[intent.Key]; !seen {
+			keys = append(keys, intent.Key)
+		}
+
+		groups[intent.Key] = append(groups[intent.Key], intent)
+	}
+
+	for _, key := range keys {
+		group := groups[key]
+		performed := map[*Intent]bool{}
+		for _, intent := range q.policy.Resolve(group) {
+			performed[intent] = true
+			intent.perform()
+		}
+
+		for _, intent := range group {
+			if !performed[intent] {
+				reject(intent, Rejected(ErrConflict.Error()))
+			}
+		}
+	}
+}
+
+// RemoveActor drops every intent queued by the actor
+func (q *IntentQueue) RemoveActor(actorId int) {
+	kept := q.intents[:0]
+	for _, intent := range q.intents {
+		if intent.ActorId != actorId {
+			kept = append(kept, intent)
+		}
+	}
+
+	q.intents = kept
+}
+
+func (q *IntentQueue) Len() int {
+	return len(q.intents)
+}
+
+// Intents writes every queued intent in queueing order, keys are left out as they are usually pointers into the world
+func (d *Digester) Intents(q *IntentQueue) *Digester {
+	d.Int(q.lastSeq, len(q.intents))
+	for _, intent := range q.intents {
+		d.Int(intent.ActorId, intent.Seq).String(intent.ActionId, intent.Name).Int(len(intent.Args))
+		for _, arg := range intent.Args {
+			d.Value(arg)
+		}
+	}
+
+	return d
+}
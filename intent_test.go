@@ -0,0 +1,76 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func enqueueTest(q *IntentQueue, actorId int, key any, performed *[]int) *Outcome {
+	return q.Enqueue(actorId, "test.action", "test", nil, key, func() *Outcome {
+		*performed = append(*performed, actorId)
+		return Success()
+	})
+}
+
+func TestIntentQueueResolve(t *testing.T) {
+	q := NewIntentQueue(ResolveInOrder)
+	var performed []int
+	assert.Equal(t, OutcomeQueued, enqueueTest(q, 2, "line", &performed).Status)
+	enqueueTest(q, 1, "line", &performed)
+	enqueueTest(q, 3, "other", &performed)
+	assert.Equal(t, 3, q.Len())
+	assert.Empty(t, performed)
+
+	q.Resolve(func(intent *Intent, outcome *Outcome) { t.Fail() })
+	assert.Equal(t, []int{2, 1, 3}, performed)
+	assert.Zero(t, q.Len())
+
+	performed = nil
+	q.SetPolicy(ResolveByActorId)
+	enqueueTest(q, 2, "line", &performed)
+	enqueueTest(q, 1, "line", &performed)
+	q.Resolve(func(intent *Intent, outcome *Outcome) { t.Fail() })
+	assert.Equal(t, []int{1, 2}, performed)
+}
+
+func TestIntentQueueConflict(t *testing.T) {
+	var performed []int
+	var rejected []*Intent
+	reject := func(intent *Intent, outcome *Outcome) {
+		assert.Equal(t, OutcomeRejected, outcome.Status)
+		assert.Equal(t, ErrConflict.Error(), outcome.Reason)
+		rejected = append(rejected, intent)
+	}
+
+	q := NewIntentQueue(ResolveLowestActorWins)
+	enqueueTest(q, 2, "line", &performed)
+	enqueueTest(q, 1, "line", &performed)
+	enqueueTest(q, 1, "line", &performed)
+	enqueueTest(q, 2, "other", &performed)
+	q.Resolve(reject)
+	assert.Equal(t, []int{1, 1, 2}, performed)
+	assert.Len(t, rejected, 1)
+	assert.Equal(t, 2, rejected[0].ActorId)
+	assert.Equal(t, "line", rejected[0].Key)
+
+	performed, rejected = nil, nil
+	q.SetPolicy(ResolveRejectContested)
+	enqueueTest(q, 2, "line", &performed)
+	enqueueTest(q, 1, "line", &performed)
+	enqueueTest(q, 2, "other", &performed)
+	q.Resolve(reject)
+	assert.Equal(t, []int{2}, performed)
+	assert.Len(t, rejected, 2)
+}
+
+func TestIntentQueueRemoveActor(t *testing.T) {
+	q := NewIntentQueue(ResolveInOrder)
+	var performed []int
+	enqueueTest(q, 1, "line", &performed)
+	enqueueTest(q, 2, "line", &performed)
+	q.RemoveActor(1)
+	assert.Equal(t, 1, q.Len())
+	q.Resolve(func(intent *Intent, outcome *Outcome) { t.Fail() })
+	assert.Equal(t, []int{2}, performed)
+}
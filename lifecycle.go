@@ -0,0 +1,99 @@
+package world
+
+import (
+	"sort"
+	"sync"
+)
+
+type LifecycleEvent int
+
+const (
+	ActorSpawned LifecycleEvent = iota
+	ActorRemoved
+)
+
+func (e LifecycleEvent) String() string {
+	switch e {
+	case ActorSpawned:
+		return "spawned"
+	case ActorRemoved:
+		return "removed"
+	}
+
+	return "unknown"
+}
+
+/*
+Lifecycle
+
+	# fans actor lifecycle events out to subscribers, i.e. an agent framework tracking its bodies
+	# each world owns one Lifecycle that survives Reset
+
+	# methods:
+		# Subscribe: registers a hook invoked on every event, returns a function that unsubscribes it
+		# Emit: invoked by worlds after an actor has been spawned or removed
+		# EmitRestored: invoked by worlds after restoring a Snapshot
+*/
+type Lifecycle struct {
+	mu    sync.Mutex
+	hooks []*lifecycleHook
+}
+
+type lifecycleHook struct {
+	f func(event LifecycleEvent, actorId int)
+}
+
+func NewLifecycle() *Lifecycle {
+	return &Lifecycle{}
+}
+
+func (l *Lifecycle) Subscribe(f func(event LifecycleEvent, actorId int)) func() {
+	hook := &lifecycleHook{f: f}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.hooks = append(l.hooks, hook)
+	return func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		for i, h := range l.hooks {
+			if h == hook {
+				l.hooks = append(l.hooks[:i:i], l.hooks[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+func (l *Lifecycle) Emit(event LifecycleEvent, actorId int) {
+	l.mu.Lock()
+	hooks := make([]*lifecycleHook, len(l.hooks))
+	copy(hooks, l.hooks)
+	l.mu.Unlock()
+
+	for _, hook := range hooks {
+		hook.f(event, actorId)
+	}
+}
+
+// EmitRestored emits ActorRemoved for the actors of before missing from after, then ActorSpawned for the actors of after missing from before, each in id order
+func (l *Lifecycle) EmitRestored(before, after map[int]bool) {
+	for _, actorId := range missingIds(before, after) {
+		l.Emit(ActorRemoved, actorId)
+	}
+
+	for _, actorId := range missingIds(after, before) {
+		l.Emit(ActorSpawned, actorId)
+	}
+}
+
+func missingIds(from, in map[int]bool) []int {
+	var result []int
+	for actorId := range from {
+		if !in[actorId] {
+			result = append(result, actorId)
+		}
+	}
+
+	sort.Ints(result)
+	return result
+}
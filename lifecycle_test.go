@@ -0,0 +1,28 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLifecycle(t *testing.T) {
+	l := NewLifecycle()
+	var events []string
+	unsubscribe := l.Subscribe(func(event LifecycleEvent, actorId int) {
+		events = append(events, event.String())
+	})
+	l.Subscribe(func(event LifecycleEvent, actorId int) {
+		assert.Equal(t, 7, actorId)
+	})
+
+	l.Emit(ActorSpawned, 7)
+	l.Emit(ActorRemoved, 7)
+	assert.Equal(t, []string{"spawned", "removed"}, events)
+
+	unsubscribe()
+	unsubscribe()
+	l.Emit(ActorSpawned, 7)
+	assert.Len(t, events, 2)
+	assert.Equal(t, "unknown", LifecycleEvent(-1).String())
+}
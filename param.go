@@ -0,0 +1,101 @@
+package world
+
+import "fmt"
+
+type ParamKind int
+
+const (
+	ParamInt ParamKind = iota
+	ParamEnum
+	ParamString
+)
+
+/*
+Param
+
+	# declares a single argument of a parameterized action
+
+	# fields:
+		# Name: the name of the argument, used for debugging and tooling
+		# Kind: the type of the argument
+		# Min, Max: inclusive bounds of a ParamInt
+		# Values: admissible values of a ParamEnum
+		# MaxLen: maximum length of a ParamString, 0 for unbounded
+*/
+type Param struct {
+	Name   string
+	Kind   ParamKind
+	Min    int
+	Max    int
+	Values []string
+	MaxLen int
+}
+
+func IntParam(name string, min, max int) *Param {
+	return &Param{Name: name, Kind: ParamInt, Min: min, Max: max}
+}
+
+func EnumParam(name string, values ...string) *Param {
+	return &Param{Name: name, Kind: ParamEnum, Values: values}
+}
+
+func StringParam(name string, maxLen int) *Param {
+	return &Param{Name: name, Kind: ParamString, MaxLen: maxLen}
+}
+
+func (p *Param) Validate(arg any) error {
+	switch p.Kind {
+	case ParamInt:
+		val, ok := arg.(int)
+		if !ok {
+			return fmt.Errorf("%w: %s must be an int", ErrInvalidArgs, p.Name)
+		}
+
+		if val < p.Min || val > p.Max {
+			return fmt.Errorf("%w: %s must be within [%d, %d]", ErrInvalidArgs, p.Name, p.Min, p.Max)
+		}
+	case ParamEnum:
+		val, ok := arg.(string)
+		if !ok {
+			return fmt.Errorf("%w: %s must be a string", ErrInvalidArgs, p.Name)
+		}
+
+		for _, v := range p.Values {
+			if v == val {
+				return nil
+			}
+		}
+
+		return fmt.Errorf("%w: %s does not accept %q", ErrInvalidArgs, p.Name, val)
+	case ParamString:
+		val, ok := arg.(string)
+		if !ok {
+			return fmt.Errorf("%w: %s must be a string", ErrInvalidArgs, p.Name)
+		}
+
+		if p.MaxLen > 0 && len(val) > p.MaxLen {
+			return fmt.Errorf("%w: %s exceeds %d characters", ErrInvalidArgs, p.Name, p.MaxLen)
+		}
+	default:
+		return fmt.Errorf("%w: %s has unknown kind", ErrInvalidArgs, p.Name)
+	}
+
+	return nil
+}
+
+// Schema declares the arguments of a parameterized action, in order
+type Schema []*Param
+
+func (s Schema) Validate(args []any) error {
+	if len(args) != len(s) {
+		return fmt.Errorf("%w: expected %d args, got %d", ErrInvalidArgs, len(s), len(args))
+	}
+
+	for i, p := range s {
+		if err := p.Validate(args[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
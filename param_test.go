@@ -0,0 +1,42 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParamValidate(t *testing.T) {
+	i := IntParam("steps", 1, 3)
+	assert.NoError(t, i.Validate(1))
+	assert.NoError(t, i.Validate(3))
+	assert.ErrorIs(t, i.Validate(0), ErrInvalidArgs)
+	assert.ErrorIs(t, i.Validate(4), ErrInvalidArgs)
+	assert.ErrorIs(t, i.Validate("1"), ErrInvalidArgs)
+
+	e := EnumParam("dir", "left", "right")
+	assert.NoError(t, e.Validate("left"))
+	assert.ErrorIs(t, e.Validate("up"), ErrInvalidArgs)
+	assert.ErrorIs(t, e.Validate(1), ErrInvalidArgs)
+
+	s := StringParam("text", 2)
+	assert.NoError(t, s.Validate("ab"))
+	assert.ErrorIs(t, s.Validate("abc"), ErrInvalidArgs)
+	assert.ErrorIs(t, s.Validate(nil), ErrInvalidArgs)
+	assert.NoError(t, StringParam("text", 0).Validate("abcdefg"))
+
+	assert.ErrorIs(t, (&Param{Kind: -1}).Validate(nil), ErrInvalidArgs)
+}
+
+func TestSchemaValidate(t *testing.T) {
+	schema := Schema{IntParam("steps", 1, 3), EnumParam("dir", "left", "right")}
+	assert.NoError(t, schema.Validate([]any{2, "left"}))
+	assert.ErrorIs(t, schema.Validate([]any{2}), ErrInvalidArgs)
+	assert.ErrorIs(t, schema.Validate([]any{"left", 2}), ErrInvalidArgs)
+	assert.NoError(t, Schema(nil).Validate(nil))
+}
+
+func TestActionInterfaceParameterized(t *testing.T) {
+	assert.False(t, (&ActionInterface{}).Parameterized())
+	assert.True(t, (&ActionInterface{StepWith: func(_ ...any) *Outcome { return Success() }}).Parameterized())
+}
@@ -0,0 +1,166 @@
+package world
+
+import (
+	"sort"
+	"sync"
+)
+
+/*
+Runtime
+
+	# runs the decision logic of every agent in its own goroutine, one turn per tick
+	# all agents take their turn concurrently, the world ticks once every agent has finished its turn (the tick barrier)
+	# the world must be safe for concurrent use, agents are stopped when their actor is removed
+
+	# fields:
+		# agents: actorId -> goroutine running the actor's decision logic
+		# turns: counts the agents still taking their turn
+		# unsubscribe: removes the runtime's lifecycle hook, called by Stop
+*/
+type Runtime struct {
+	w           SafeWorld
+	mu          sync.Mutex
+	agents      map[int]*runtimeAgent
+	turns       sync.WaitGroup
+	unsubscribe func()
+}
+
+type runtimeAgent struct {
+	actorId int
+	decide  func()
+	turn    chan struct{}
+	err     error
+}
+
+func NewRuntime(w SafeWorld) *Runtime {
+	result := &Runtime{
+		w:      w,
+		agents: map[int]*runtimeAgent{},
+	}
+
+	if lifecycle := w.Lifecycle(); lifecycle != nil {
+		result.unsubscribe = lifecycle.Subscribe(func(event LifecycleEvent, actorId int) {
+			if event == ActorRemoved {
+				result.Remove(actorId)
+			}
+		})
+	}
+
+	return result
+}
+
+// Spawn starts the goroutine running decide once per tick on behalf of the actor
+func (r *Runtime) Spawn(actorId int, decide func()) error {
+	if decide == nil {
+		return ErrInvalidArgs
+	}
+
+	if _, err := r.w.Actions(actorId); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, seen := r.agents[actorId]; seen {
+		return ErrCycleExists
+	}
+
+	agent := &runtimeAgent{actorId: actorId, decide: decide, turn: make(chan struct{}, 1)}
+	r.agents[actorId] = agent
+	go r.loop(agent)
+	return nil
+}
+
+func (r *Runtime) loop(agent *runtimeAgent) {
+	for range agent.turn {
+		agent.err = r.take(agent)
+		r.turns.Done()
+	}
+}
+
+func (r *Runtime) take(agent *runtimeAgent) (err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			err = panicErr(rec)
+		}
+	}()
+
+	agent.decide()
+	return nil
+}
+
+// Remove stops the actor's goroutine after its current turn, returns false if the actor had no agent
+func (r *Runtime) Remove(actorId int) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	agent, seen := r.agents[actorId]
+	if !seen {
+		return false
+	}
+
+	delete(r.agents, actorId)
+	close(agent.turn)
+	return true
+}
+
+// Step gives every agent one turn, waits for all of them, then ticks the world
+// the first panic raised by an agent, in actor id order, is returned after the tick
+func (r *Runtime) Step() error {
+	r.mu.Lock()
+	var agents []*runtimeAgent
+	for _, agent := range r.agents {
+		agents = append(agents, agent)
+	}
+
+	sort.Slice(agents, func(i, j int) bool {
+		return agents[i].actorId < agents[j].actorId
+	})
+
+	r.turns.Add(len(agents))
+	for _, agent := range agents {
+		agent.turn <- struct{}{}
+	}
+	r.mu.Unlock()
+
+	r.turns.Wait()
+	r.w.Tick()
+
+	for _, agent := range agents {
+		if agent.err != nil {
+			return agent.err
+		}
+	}
+
+	return nil
+}
+
+// Run steps the runtime the given number of ticks, stopping at the first agent failure
+func (r *Runtime) Run(ticks int) error {
+	for tick := 0; tick < ticks; tick++ {
+		if err := r.Step(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Stop ends every agent goroutine and stops following the world's lifecycle
+func (r *Runtime) Stop() {
+	r.mu.Lock()
+	unsubscribe := r.unsubscribe
+	r.unsubscribe = nil
+	var actorIds []int
+	for actorId := range r.agents {
+		actorIds = append(actorIds, actorId)
+	}
+	r.mu.Unlock()
+
+	if unsubscribe != nil {
+		unsubscribe()
+	}
+
+	for _, actorId := range actorIds {
+		r.Remove(actorId)
+	}
+}
@@ -0,0 +1,85 @@
+package world
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type barrierWorld struct {
+	panicWorld
+	ticks   int64
+	lastId  int
+	removed []int
+}
+
+func (w *barrierWorld) NewActor(_ ...any) (int, []*ActionInterface) {
+	w.lastId++
+	return w.lastId, nil
+}
+
+func (w *barrierWorld) Tick() {
+	atomic.AddInt64(&w.ticks, 1)
+}
+
+func (w *barrierWorld) RemoveActor(actorId int) {
+	w.removed = append(w.removed, actorId)
+}
+
+func TestRuntime(t *testing.T) {
+	bw := &barrierWorld{}
+	w := Recover(bw)
+	r := NewRuntime(w)
+	defer r.Stop()
+
+	assert.ErrorIs(t, r.Spawn(1, func() {}), ErrActorNotFound)
+	seen := make([][]int64, 4)
+	for i := range seen {
+		actorId, _, _ := w.NewActor()
+		i := i
+		assert.NoError(t, r.Spawn(actorId, func() {
+			seen[i] = append(seen[i], atomic.LoadInt64(&bw.ticks))
+		}))
+	}
+
+	assert.ErrorIs(t, r.Spawn(1, func() {}), ErrCycleExists)
+	assert.ErrorIs(t, r.Spawn(1, nil), ErrInvalidArgs)
+
+	// every agent finishes its turn before the world ticks
+	assert.NoError(t, r.Run(5))
+	for _, s := range seen {
+		assert.Equal(t, []int64{0, 1, 2, 3, 4}, s)
+	}
+
+	// removed actors stop taking turns
+	assert.NoError(t, w.RemoveActor(1))
+	assert.False(t, r.Remove(1))
+	assert.NoError(t, r.Step())
+	assert.Len(t, seen[0], 5)
+	assert.Len(t, seen[1], 6)
+}
+
+func TestRuntimeStop(t *testing.T) {
+	w := Recover(&barrierWorld{})
+	r := NewRuntime(w)
+	assert.Len(t, w.Lifecycle().hooks, 1)
+
+	r.Stop()
+	assert.Empty(t, w.Lifecycle().hooks)
+	r.Stop()
+}
+
+func TestRuntimePanic(t *testing.T) {
+	bw := &barrierWorld{}
+	w := Recover(bw)
+	r := NewRuntime(w)
+	defer r.Stop()
+
+	actorId, _, _ := w.NewActor()
+	boom := errors.New("boom")
+	assert.NoError(t, r.Spawn(actorId, func() { panic(boom) }))
+	assert.ErrorIs(t, r.Run(3), boom)
+	assert.Equal(t, int64(1), atomic.LoadInt64(&bw.ticks))
+}
@@ -0,0 +1,319 @@
+package world
+
+import "sort"
+
+/*
+Scheduler
+
+	# enforces action durations, cooldowns and per-actor action budgets, shared by all worlds
+	# a world wraps the action interfaces it hands out with Wrap and calls Advance on every Tick
+	# not safe for concurrent use, concurrent worlds only touch it while holding their own lock
+
+	# rules:
+		# an action with Duration n > 0 is performed n ticks after its Step, the actor is busy meanwhile
+		# an action with Cooldown n > 0 is unavailable for n ticks after it was performed
+		# an action whose step only queued an intent is performed once the world calls Applied, the cooldown starts then
+		# until the world calls Settle, a queued action with a cooldown is unavailable as well
+		# an actor may start at most budget actions per tick, 0 for unlimited
+
+	# fields:
+		# tick: number of Advance calls so far
+		# spent: actorId -> actions started during the current tick
+		# cooldowns: actorId -> action -> first tick the action is available again
+		# busy: actorId -> action in progress
+		# queued: actorId -> inner action -> wrapped action, for queued actions waiting for their cooldown
+*/
+type Scheduler struct {
+	tick      int
+	budget    int
+	spent     map[int]int
+	cooldowns map[int]map[*ActionInterface]int
+	busy      map[int]*scheduledAction
+	queued    map[int]map[*ActionInterface]*ActionInterface
+}
+
+type scheduledAction struct {
+	outer   *ActionInterface
+	inner   *ActionInterface
+	args    []any
+	withArg bool
+	due     int
+}
+
+func NewScheduler() *Scheduler {
+	return &Scheduler{
+		spent:     map[int]int{},
+		cooldowns: map[int]map[*ActionInterface]int{},
+		busy:      map[int]*scheduledAction{},
+		queued:    map[int]map[*ActionInterface]*ActionInterface{},
+	}
+}
+
+func (s *Scheduler) SetBudget(budget int) {
+	s.budget = budget
+}
+
+func (s *Scheduler) check(actorId int, a *ActionInterface) error {
+	if _, busy := s.busy[actorId]; busy {
+		return ErrActorBusy
+	}
+
+	if s.budget > 0 && s.spent[actorId] >= s.budget {
+		return ErrBudgetExhausted
+	}
+
+	if s.tick < s.cooldowns[actorId][a] {
+		return ErrCooldown
+	}
+
+	for _, queued := range s.queued[actorId] {
+		if queued == a {
+			return ErrCooldown
+		}
+	}
+
+	return nil
+}
+
+/*
+Wrap
+
+	# returns an action interface carrying the same metadata as inner, guarded by the scheduler
+	# Duration and Cooldown are read from the returned action interface, so a world may adjust them later
+	# report receives the outcomes produced by the scheduler itself, i.e. rejections and pending actions
+	# outcomes of inner are returned as is, inner is expected to report them itself
+*/
+func (s *Scheduler) Wrap(actorId int, inner *ActionInterface, report func(outcome *Outcome)) *ActionInterface {
+	outer := *inner
+	outer.Ready = func() bool {
+		return s.check(actorId, &outer) == nil && (inner.Ready == nil || inner.Ready())
+	}
+	outer.Diagnose = func() error {
+		if err := s.check(actorId, &outer); err != nil {
+			return err
+		}
+
+		return inner.Why()
+	}
+	outer.Step = func() *Outcome {
+		return s.start(actorId, &outer, inner, nil, false, report)
+	}
+	if inner.StepWith != nil {
+		outer.StepWith = func(args ...any) *Outcome {
+			return s.start(actorId, &outer, inner, args, true, report)
+		}
+	}
+
+	return &outer
+}
+
+func (s *Scheduler) start(actorId int, outer, inner *ActionInterface, args []any, withArgs bool, report func(*Outcome)) *Outcome {
+	if err := s.check(actorId, outer); err != nil {
+		outcome := Rejected(err.Error())
+		report(outcome)
+		return outcome
+	}
+
+	s.spent[actorId]++
+	scheduled := &scheduledAction{outer: outer, inner: inner, args: args, withArg: withArgs}
+	if outer.Duration <= 0 {
+		return s.perform(actorId, scheduled)
+	}
+
+	if err := inner.Why(); err != nil {
+		outcome := Rejected(err.Error())
+		report(outcome)
+		return outcome
+	}
+
+	scheduled.due = s.tick + outer.Duration
+	s.busy[actorId] = scheduled
+	outcome := Pending()
+	report(outcome)
+	return outcome
+}
+
+func (s *Scheduler) perform(actorId int, scheduled *scheduledAction) *Outcome {
+	var outcome *Outcome
+	if scheduled.withArg {
+		outcome = scheduled.inner.StepWith(scheduled.args...)
+	} else {
+		outcome = scheduled.inner.Step()
+	}
+
+	if scheduled.outer.Cooldown <= 0 || outcome.Status == OutcomeRejected {
+		return outcome
+	}
+
+	if outcome.Status == OutcomeQueued {
+		if s.queued[actorId] == nil {
+			s.queued[actorId] = map[*ActionInterface]*ActionInterface{}
+		}
+
+		s.queued[actorId][scheduled.inner] = scheduled.outer
+		return outcome
+	}
+
+	s.cool(actorId, scheduled.outer)
+	return outcome
+}
+
+func (s *Scheduler) cool(actorId int, outer *ActionInterface) {
+	if s.cooldowns[actorId] == nil {
+		s.cooldowns[actorId] = map[*ActionInterface]int{}
+	}
+
+	s.cooldowns[actorId][outer] = s.tick + outer.Cooldown
+}
+
+// Applied starts the cooldown of a queued action once the world performed its intent, inner is the action given to Wrap
+func (s *Scheduler) Applied(actorId int, inner *ActionInterface) {
+	if outer, seen := s.queued[actorId][inner]; seen {
+		s.cool(actorId, outer)
+	}
+}
+
+// Settle forgets the queued actions once the world resolved their intents, those never applied start no cooldown
+func (s *Scheduler) Settle() {
+	s.queued = map[int]map[*ActionInterface]*ActionInterface{}
+}
+
+// Advance moves the scheduler to the next tick, refreshing budgets and completing due actions in actor id order
+func (s *Scheduler) Advance() {
+	s.tick++
+	s.spent = map[int]int{}
+
+	var due []int
+	for actorId, scheduled := range s.busy {
+		if scheduled.due <= s.tick {
+			due = append(due, actorId)
+		}
+	}
+
+	sort.Ints(due)
+	for _, actorId := range due {
+		scheduled := s.busy[actorId]
+		delete(s.busy, actorId)
+		s.perform(actorId, scheduled)
+	}
+}
+
+// RemoveActor forgets the actor, dropping any action it has in progress
+func (s *Scheduler) RemoveActor(actorId int) {
+	delete(s.spent, actorId)
+	delete(s.cooldowns, actorId)
+	delete(s.busy, actorId)
+	delete(s.queued, actorId)
+}
+
+// Clear forgets every actor, budget and tick in place, action interfaces wrapped earlier keep using this scheduler
+func (s *Scheduler) Clear() {
+	*s = *NewScheduler()
+}
+
+// SchedulerState is a copy of a scheduler's budgets, cooldowns and actions in progress, see Save and Load
+type SchedulerState struct {
+	s *Scheduler
+}
+
+func (s *Scheduler) copy() *Scheduler {
+	result := &Scheduler{
+		tick:      s.tick,
+		budget:    s.budget,
+		spent:     map[int]int{},
+		cooldowns: map[int]map[*ActionInterface]int{},
+		busy:      map[int]*scheduledAction{},
+		queued:    map[int]map[*ActionInterface]*ActionInterface{},
+	}
+
+	for actorId, spent := range s.spent {
+		result.spent[actorId] = spent
+	}
+
+	for actorId, cooldowns := range s.cooldowns {
+		result.cooldowns[actorId] = map[*ActionInterface]int{}
+		for action, tick := range cooldowns {
+			result.cooldowns[actorId][action] = tick
+		}
+	}
+
+	for actorId, scheduled := range s.busy {
+		copied := *scheduled
+		result.busy[actorId] = &copied
+	}
+
+	return result
+}
+
+func (s *Scheduler) Save() *SchedulerState {
+	return &SchedulerState{s: s.copy()}
+}
+
+// Load puts back a saved state, the action interfaces it refers to keep working as they were wrapped by this scheduler
+// queued actions are not part of the state, like the intents of a world they do not survive restoring
+func (s *Scheduler) Load(state *SchedulerState) {
+	*s = *state.s.copy()
+}
+
+// Scheduler writes the tick, budget, spent actions, cooldowns and actions in progress of s in actor id order
+// cooldowns are keyed by action id, actions in progress are written with their arguments and due tick
+func (d *Digester) Scheduler(s *Scheduler) *Digester {
+	d.Int(s.tick, s.budget)
+	var spent, cooling, busy []int
+	for actorId := range s.spent {
+		spent = append(spent, actorId)
+	}
+
+	for actorId := range s.cooldowns {
+		cooling = append(cooling, actorId)
+	}
+
+	for actorId := range s.busy {
+		busy = append(busy, actorId)
+	}
+
+	sort.Ints(spent)
+	sort.Ints(cooling)
+	sort.Ints(busy)
+	d.Int(len(spent))
+	for _, actorId := range spent {
+		d.Int(actorId, s.spent[actorId])
+	}
+
+	d.Int(len(cooling))
+	for _, actorId := range cooling {
+		var actions []*ActionInterface
+		for action := range s.cooldowns[actorId] {
+			actions = append(actions, action)
+		}
+
+		sort.Slice(actions, func(i, j int) bool {
+			if actions[i].Id != actions[j].Id {
+				return actions[i].Id < actions[j].Id
+			}
+
+			return s.cooldowns[actorId][actions[i]] < s.cooldowns[actorId][actions[j]]
+		})
+
+		d.Int(actorId, len(actions))
+		for _, action := range actions {
+			d.String(action.Id).Int(s.cooldowns[actorId][action])
+		}
+	}
+
+	d.Int(len(busy))
+	for _, actorId := range busy {
+		scheduled := s.busy[actorId]
+		d.Int(actorId).String(scheduled.outer.Id).Bool(scheduled.withArg).Int(scheduled.due, len(scheduled.args))
+		for _, arg := range scheduled.args {
+			d.Value(arg)
+		}
+	}
+
+	return d
+}
+
+func (s *Scheduler) Busy(actorId int) bool {
+	_, busy := s.busy[actorId]
+	return busy
+}
@@ -0,0 +1,180 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type scheduledTestAction struct {
+	performed int
+	args      []any
+	reported  []*Outcome
+}
+
+func (c *scheduledTestAction) wrap(s *Scheduler, actorId int) *ActionInterface {
+	inner := &ActionInterface{
+		Name:  "test",
+		Id:    "test.action",
+		Ready: func() bool { return true },
+		Step: func() *Outcome {
+			c.performed++
+			return Success()
+		},
+		StepWith: func(args ...any) *Outcome {
+			c.performed++
+			c.args = args
+			return Success()
+		},
+	}
+
+	return s.Wrap(actorId, inner, func(outcome *Outcome) {
+		c.reported = append(c.reported, outcome)
+	})
+}
+
+func TestSchedulerInstant(t *testing.T) {
+	s := NewScheduler()
+	c := &scheduledTestAction{}
+	a := c.wrap(s, 1)
+	assert.Equal(t, "test.action", a.Id)
+	assert.True(t, a.Ready())
+	assert.Equal(t, OutcomeSuccess, a.Step().Status)
+	assert.Equal(t, OutcomeSuccess, a.StepWith(1, "x").Status)
+	assert.Equal(t, 2, c.performed)
+	assert.Equal(t, []any{1, "x"}, c.args)
+	assert.Empty(t, c.reported)
+}
+
+func TestSchedulerDuration(t *testing.T) {
+	s := NewScheduler()
+	c := &scheduledTestAction{}
+	a := c.wrap(s, 1)
+	other := (&scheduledTestAction{}).wrap(s, 1)
+	a.Duration = 2
+
+	assert.Equal(t, OutcomePending, a.Step().Status)
+	assert.True(t, s.Busy(1))
+	assert.Zero(t, c.performed)
+	assert.ErrorIs(t, other.Why(), ErrActorBusy)
+	assert.Equal(t, OutcomeRejected, other.Step().Status)
+
+	s.Advance()
+	assert.Zero(t, c.performed)
+	s.Advance()
+	assert.Equal(t, 1, c.performed)
+	assert.False(t, s.Busy(1))
+	assert.NoError(t, other.Why())
+	assert.Len(t, c.reported, 1)
+	assert.Equal(t, OutcomePending, c.reported[0].Status)
+}
+
+func TestSchedulerCooldown(t *testing.T) {
+	s := NewScheduler()
+	c := &scheduledTestAction{}
+	a := c.wrap(s, 1)
+	a.Cooldown = 2
+
+	a.Step()
+	assert.ErrorIs(t, a.Why(), ErrCooldown)
+	assert.False(t, a.Ready())
+	assert.Equal(t, OutcomeRejected, a.Step().Status)
+	assert.Equal(t, 1, c.performed)
+
+	s.Advance()
+	assert.ErrorIs(t, a.Why(), ErrCooldown)
+	s.Advance()
+	assert.NoError(t, a.Why())
+
+	// cooldowns are per actor
+	b := (&scheduledTestAction{}).wrap(s, 2)
+	b.Cooldown = 2
+	a.Step()
+	assert.NoError(t, b.Why())
+}
+
+func TestSchedulerQueuedCooldown(t *testing.T) {
+	s := NewScheduler()
+	inner := &ActionInterface{Id: "test.queued", Step: func() *Outcome { return Queued() }}
+	a := s.Wrap(1, inner, func(*Outcome) {})
+	a.Cooldown = 2
+
+	// the cooldown waits for the intent to be applied
+	assert.Equal(t, OutcomeQueued, a.Step().Status)
+	assert.ErrorIs(t, a.Why(), ErrCooldown)
+	s.Settle()
+	assert.NoError(t, a.Why())
+
+	a.Step()
+	s.Applied(1, inner)
+	s.Settle()
+	assert.ErrorIs(t, a.Why(), ErrCooldown)
+	s.Advance()
+	s.Advance()
+	assert.NoError(t, a.Why())
+}
+
+func TestSchedulerBudget(t *testing.T) {
+	s := NewScheduler()
+	s.SetBudget(2)
+	c := &scheduledTestAction{}
+	a := c.wrap(s, 1)
+	b := (&scheduledTestAction{}).wrap(s, 2)
+
+	a.Step()
+	a.Step()
+	assert.ErrorIs(t, a.Why(), ErrBudgetExhausted)
+	assert.Equal(t, OutcomeRejected, a.Step().Status)
+	assert.Equal(t, 2, c.performed)
+	assert.NoError(t, b.Why())
+
+	s.Advance()
+	assert.NoError(t, a.Why())
+}
+
+func TestSchedulerRemoveActor(t *testing.T) {
+	s := NewScheduler()
+	c := &scheduledTestAction{}
+	a := c.wrap(s, 1)
+	a.Duration = 1
+	a.Step()
+	s.RemoveActor(1)
+	s.Advance()
+	assert.Zero(t, c.performed)
+}
+
+func TestSchedulerRejectsIllegalMultiTick(t *testing.T) {
+	s := NewScheduler()
+	inner := &ActionInterface{
+		Ready: func() bool { return false },
+		Step:  func() *Outcome { return Success() },
+	}
+	a := s.Wrap(1, inner, func(*Outcome) {})
+	a.Duration = 3
+	assert.Equal(t, OutcomeRejected, a.Step().Status)
+	assert.False(t, s.Busy(1))
+	assert.Nil(t, a.StepWith)
+}
+
+func TestSchedulerSaveLoad(t *testing.T) {
+	s := NewScheduler()
+	c := &scheduledTestAction{}
+	a := c.wrap(s, 1)
+	a.Duration = 2
+	a.Cooldown = 1
+
+	assert.Equal(t, OutcomePending, a.Step().Status)
+	state := s.Save()
+	for i := 0; i < 2; i++ {
+		s.Advance()
+		s.Advance()
+		assert.False(t, s.Busy(1))
+		assert.Equal(t, i+1, c.performed)
+		assert.ErrorIs(t, a.Why(), ErrCooldown)
+
+		// the saved state can be loaded repeatedly, the action is in progress again
+		s.Load(state)
+		assert.True(t, s.Busy(1))
+		assert.ErrorIs(t, a.Why(), ErrActorBusy)
+	}
+}
@@ -0,0 +1,210 @@
+package world
+
+import "sync"
+
+/*
+Session
+
+	# owns a world, its unit id allocator and the facade methods operating on them
+	# sessions are fully isolated from one another, several may run in the same process
+	# the package-level facade functions operate on the default session
+
+	# fields:
+		# world: the world currently driven by this session
+		# lastUnitId: the last unit id handed out by NewUnitId
+		# deltas: observation deltas of worlds that do not implement DeltaLooker
+
+	# safe for concurrent use once its world is installed, SetWorld and SetSafeWorld are not
+*/
+type Session struct {
+	mu         sync.Mutex
+	world      SafeWorld
+	lastUnitId int
+	deltas     *DeltaTracker
+}
+
+func NewSession() *Session {
+	return &Session{deltas: NewDeltaTracker()}
+}
+
+var defaultSession = NewSession()
+
+// DefaultSession returns the session backing the package-level facade functions
+func DefaultSession() *Session {
+	return defaultSession
+}
+
+func (s *Session) NewUnitId() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastUnitId++
+	return s.lastUnitId
+}
+
+// LastUnitId returns the last unit id handed out, worlds save it with their snapshots
+func (s *Session) LastUnitId() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastUnitId
+}
+
+// SetLastUnitId moves the unit id allocator back to a saved position, so a restored episode hands out the same ids again
+func (s *Session) SetLastUnitId(id int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastUnitId = id
+}
+
+// SetWorld installs a panicking World, wrapped with Recover
+func (s *Session) SetWorld(w World) {
+	s.SetSafeWorld(Recover(w))
+}
+
+func (s *Session) SetSafeWorld(w SafeWorld) {
+	s.world = w
+	s.mu.Lock()
+	s.deltas = NewDeltaTracker()
+	s.mu.Unlock()
+}
+
+func (s *Session) GetWorld() World {
+	return Must(s.world)
+}
+
+func (s *Session) GetSafeWorld() SafeWorld {
+	return s.world
+}
+
+func (s *Session) Reset() {
+	s.mu.Lock()
+	s.lastUnitId = 0
+	s.deltas = NewDeltaTracker()
+	s.mu.Unlock()
+	s.world.Reset()
+}
+
+func (s *Session) Tick() {
+	s.world.Tick()
+}
+
+func (s *Session) Clock() int {
+	return s.world.Clock()
+}
+
+func (s *Session) Snapshot() (*Snapshot, error) {
+	return s.world.Snapshot()
+}
+
+// Restore brings the world back to the snapshot, the next LookDelta of every actor reports its full observation
+func (s *Session) Restore(snapshot *Snapshot) error {
+	if err := s.world.Restore(snapshot); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.deltas = NewDeltaTracker()
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *Session) Digest() (Digest, error) {
+	return s.world.Digest()
+}
+
+// NewActor goes through the SafeWorld rather than GetWorld, so that the actor is tracked and ActorSpawned is emitted
+func (s *Session) NewActor(args ...any) (int, []*ActionInterface) {
+	id, actions, err := s.world.NewActor(args...)
+	if err != nil {
+		panic(err)
+	}
+
+	return id, actions
+}
+
+func (s *Session) TryNewActor(args ...any) (int, []*ActionInterface, error) {
+	return s.world.NewActor(args...)
+}
+
+func (s *Session) Register(id int, cycle func(), opts ...CycleOption) *CycleHandle {
+	handle, err := s.world.Register(id, cycle, opts...)
+	if err != nil {
+		panic(err)
+	}
+
+	return handle
+}
+
+func (s *Session) TryRegister(id int, cycle func(), opts ...CycleOption) (*CycleHandle, error) {
+	return s.world.Register(id, cycle, opts...)
+}
+
+func (s *Session) RemoveActor(id int) {
+	if err := s.TryRemoveActor(id); err != nil {
+		panic(err)
+	}
+}
+
+func (s *Session) TryRemoveActor(id int) error {
+	s.mu.Lock()
+	s.deltas.RemoveActor(id)
+	s.mu.Unlock()
+	return s.world.RemoveActor(id)
+}
+
+func (s *Session) GetLifecycle() *Lifecycle {
+	return s.world.Lifecycle()
+}
+
+func (s *Session) Actions(id int) []*ActionInterface {
+	actions, err := s.world.Actions(id)
+	if err != nil {
+		panic(err)
+	}
+
+	return actions
+}
+
+func (s *Session) TryActions(id int) ([]*ActionInterface, error) {
+	return s.world.Actions(id)
+}
+
+func (s *Session) Look(id int) []*Image {
+	return s.world.Look(id)
+}
+
+// Subscribe streams the actor's observations, the world or a world it wraps must have been wrapped with Stream
+func (s *Session) Subscribe(id int) (*Subscription, error) {
+	for curr := s.world; curr != nil; curr = Unwrap(curr) {
+		if streamer, ok := curr.(*StreamWorld); ok {
+			return streamer.Subscribe(id)
+		}
+	}
+
+	return nil, ErrUnsupported
+}
+
+// LookDelta returns the change of the actor's observation since its previous LookDelta
+func (s *Session) LookDelta(id int) *ImageDelta {
+	if looker, ok := s.world.(DeltaLooker); ok {
+		return looker.LookDelta(id)
+	}
+
+	images := s.world.Look(id)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.deltas.Diff(id, images)
+}
+
+func (s *Session) Feel(id int) []*Touch {
+	return s.world.Feel(id)
+}
+
+func (s *Session) Cmd(args ...any) {
+	if err := s.world.Cmd(args...); err != nil {
+		panic(err)
+	}
+}
+
+func (s *Session) TryCmd(args ...any) error {
+	return s.world.Cmd(args...)
+}
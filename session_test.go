@@ -0,0 +1,71 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSessionIsolation(t *testing.T) {
+	s1, s2 := NewSession(), NewSession()
+	pw1, pw2 := &panicWorld{}, &panicWorld{panicWith: ErrInvalidArgs}
+	s1.SetWorld(pw1)
+	s2.SetWorld(pw2)
+	assert.Equal(t, pw1, s1.GetWorld())
+	assert.Equal(t, pw2, s2.GetWorld())
+	assert.NotSame(t, DefaultSession(), s1)
+
+	s1.Reset()
+	s2.Reset()
+	assert.Equal(t, 1, s1.NewUnitId())
+	assert.Equal(t, 2, s1.NewUnitId())
+	assert.Equal(t, 1, s2.NewUnitId())
+
+	_, _, err := s1.TryNewActor()
+	assert.NoError(t, err)
+	_, _, err = s2.TryNewActor()
+	assert.ErrorIs(t, err, ErrInvalidArgs)
+	assert.NoError(t, s1.TryCmd())
+	assert.ErrorIs(t, s2.TryCmd(), ErrInvalidArgs)
+}
+
+func TestDefaultSession(t *testing.T) {
+	pw := &panicWorld{}
+	SetWorld(pw)
+	assert.Equal(t, pw, DefaultSession().GetWorld())
+
+	Reset()
+	id := NewUnitId()
+	assert.Equal(t, id+1, DefaultSession().NewUnitId())
+}
+
+func TestFacadeNewActor(t *testing.T) {
+	SetWorld(&panicWorld{})
+	var spawned []int
+	GetLifecycle().Subscribe(func(event LifecycleEvent, actorId int) {
+		if event == ActorSpawned {
+			spawned = append(spawned, actorId)
+		}
+	})
+
+	actorId, actions := NewActor()
+	assert.Equal(t, []int{actorId}, spawned)
+	assert.Equal(t, actions, Actions(actorId))
+	_, err := DefaultSession().TryActions(actorId)
+	assert.NoError(t, err)
+}
+
+func TestSessionSnapshot(t *testing.T) {
+	s := NewSession()
+	s.SetWorld(&snapshotPanicWorld{})
+	s.Reset()
+	s.NewUnitId()
+	assert.Equal(t, 1, s.LastUnitId())
+	snapshot, err := s.Snapshot()
+	assert.NoError(t, err)
+
+	s.SetLastUnitId(5)
+	assert.Equal(t, 6, s.NewUnitId())
+	assert.NoError(t, s.Restore(snapshot))
+	assert.ErrorIs(t, s.Restore(nil), ErrInvalidArgs)
+}
@@ -0,0 +1,284 @@
+package world
+
+/*
+Recover
+
+	# shim turning a panicking World into a SafeWorld
+	# any panic raised by NewActor, Register or Cmd is recovered and returned as an error
+	# RemoveActor is forwarded if the World has a RemoveActor(actorId int) method, ErrUnsupported otherwise
+	# Snapshot and Restore are forwarded if the World has Snapshot() *Snapshot and Restore(*Snapshot) methods, ErrUnsupported otherwise
+	# Digest is forwarded if the World has a Digest() Digest method, ErrUnsupported otherwise
+	# Recover(Must(w)) returns w itself
+*/
+func Recover(w World) SafeWorld {
+	if w == nil {
+		return nil
+	}
+
+	if m, ok := w.(*mustWorld); ok {
+		return m.SafeWorld
+	}
+
+	return &recoverWorld{
+		World:     w,
+		cycles:    map[int]*CycleRegistry{},
+		actions:   map[int][]*ActionInterface{},
+		lifecycle: NewLifecycle(),
+	}
+}
+
+/*
+Must
+
+	# shim turning a SafeWorld into a World that panics with the returned error
+	# Must(Recover(w)) returns w itself
+*/
+func Must(w SafeWorld) World {
+	if w == nil {
+		return nil
+	}
+
+	if r, ok := w.(*recoverWorld); ok {
+		return r.World
+	}
+
+	return &mustWorld{SafeWorld: w}
+}
+
+// a World only holds one cycle function per actor,
+// so recoverWorld registers a single dispatcher per actor and keeps the actual cycles itself
+type recoverWorld struct {
+	World
+	cycles    map[int]*CycleRegistry     // actorId -> cycles of that actor
+	actions   map[int][]*ActionInterface // actorId -> actions returned by NewActor
+	lifecycle *Lifecycle
+	clock     int
+}
+
+type actorRemover interface {
+	RemoveActor(actorId int)
+}
+
+type snapshotter interface {
+	Snapshot() *Snapshot
+	Restore(snapshot *Snapshot)
+}
+
+type digester interface {
+	Digest() Digest
+}
+
+// recoverState wraps the World's own snapshot with the shim's bookkeeping
+type recoverState struct {
+	owner   *recoverWorld
+	inner   *Snapshot
+	actions map[int][]*ActionInterface
+}
+
+func (w *recoverWorld) Reset() {
+	for _, actorCycles := range w.cycles {
+		actorCycles.Clear()
+	}
+
+	w.cycles = map[int]*CycleRegistry{}
+	w.actions = map[int][]*ActionInterface{}
+	w.clock = 0
+	w.World.Reset()
+}
+
+func (w *recoverWorld) Tick() {
+	w.clock++
+	w.World.Tick()
+}
+
+// Clock counts the ticks passed through the shim, a World does not expose its own clock
+func (w *recoverWorld) Clock() int {
+	return w.clock
+}
+
+func (w *recoverWorld) NewActor(args ...any) (id int, actions []*ActionInterface, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			id, actions, err = 0, nil, panicErr(r)
+		}
+	}()
+
+	id, actions = w.World.NewActor(args...)
+	w.actions[id] = actions
+	w.lifecycle.Emit(ActorSpawned, id)
+	return id, actions, nil
+}
+
+func (w *recoverWorld) Register(actorId int, cycle func(), opts ...CycleOption) (handle *CycleHandle, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			handle, err = nil, panicErr(r)
+		}
+	}()
+
+	actorCycles, seen := w.cycles[actorId]
+	if !seen {
+		actorCycles = NewCycleRegistry()
+	}
+
+	// registering the dispatcher every time lets the World validate the actor
+	w.World.Register(actorId, actorCycles.Run)
+	w.cycles[actorId] = actorCycles
+	return actorCycles.Register(actorId, cycle, opts...)
+}
+
+func (w *recoverWorld) Cmd(args ...any) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = panicErr(r)
+		}
+	}()
+
+	w.World.Cmd(args...)
+	return nil
+}
+
+func (w *recoverWorld) RemoveActor(actorId int) (err error) {
+	remover, ok := w.World.(actorRemover)
+	if !ok {
+		return ErrUnsupported
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = panicErr(r)
+		}
+	}()
+
+	remover.RemoveActor(actorId)
+	if actorCycles, seen := w.cycles[actorId]; seen {
+		actorCycles.RemoveActor(actorId)
+		delete(w.cycles, actorId)
+	}
+
+	delete(w.actions, actorId)
+
+	w.lifecycle.Emit(ActorRemoved, actorId)
+	return nil
+}
+
+func (w *recoverWorld) Snapshot() (snapshot *Snapshot, err error) {
+	saver, ok := w.World.(snapshotter)
+	if !ok {
+		return nil, ErrUnsupported
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			snapshot, err = nil, panicErr(r)
+		}
+	}()
+
+	inner := saver.Snapshot()
+	state := &recoverState{owner: w, inner: inner, actions: map[int][]*ActionInterface{}}
+	for actorId, actions := range w.actions {
+		state.actions[actorId] = actions
+	}
+
+	return &Snapshot{World: w.Name(), Clock: w.clock, UnitId: inner.UnitId, State: state}, nil
+}
+
+func (w *recoverWorld) Restore(snapshot *Snapshot) (err error) {
+	saver, ok := w.World.(snapshotter)
+	if !ok {
+		return ErrUnsupported
+	}
+
+	if snapshot == nil {
+		return ErrInvalidArgs
+	}
+
+	state, ok := snapshot.State.(*recoverState)
+	if !ok || state.owner != w {
+		return ErrSnapshotMismatch
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = panicErr(r)
+		}
+	}()
+
+	saver.Restore(state.inner)
+	before, after := map[int]bool{}, map[int]bool{}
+	for actorId := range w.actions {
+		before[actorId] = true
+	}
+
+	w.actions = map[int][]*ActionInterface{}
+	for actorId, actions := range state.actions {
+		after[actorId] = true
+		w.actions[actorId] = actions
+	}
+
+	for actorId, actorCycles := range w.cycles {
+		if !after[actorId] {
+			actorCycles.RemoveActor(actorId)
+			delete(w.cycles, actorId)
+		}
+	}
+
+	w.clock = snapshot.Clock
+	w.lifecycle.EmitRestored(before, after)
+	return nil
+}
+
+// Digest combines the World's digest with the clock kept by the shim
+func (w *recoverWorld) Digest() (digest Digest, err error) {
+	inner, ok := w.World.(digester)
+	if !ok {
+		return Digest{}, ErrUnsupported
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			digest, err = Digest{}, panicErr(r)
+		}
+	}()
+
+	return NewDigester().String(w.Name()).Int(w.clock).Nested(inner.Digest()).Sum(), nil
+}
+
+func (w *recoverWorld) Lifecycle() *Lifecycle {
+	return w.lifecycle
+}
+
+// Actions lists the actions returned by NewActor calls made through the shim
+func (w *recoverWorld) Actions(actorId int) ([]*ActionInterface, error) {
+	actions, seen := w.actions[actorId]
+	if !seen {
+		return nil, ErrActorNotFound
+	}
+
+	return actions, nil
+}
+
+type mustWorld struct {
+	SafeWorld
+}
+
+func (w *mustWorld) NewActor(args ...any) (int, []*ActionInterface) {
+	id, actions, err := w.SafeWorld.NewActor(args...)
+	if err != nil {
+		panic(err)
+	}
+
+	return id, actions
+}
+
+func (w *mustWorld) Register(actorId int, cycle func()) {
+	if _, err := w.SafeWorld.Register(actorId, cycle); err != nil {
+		panic(err)
+	}
+}
+
+func (w *mustWorld) Cmd(args ...any) {
+	if err := w.SafeWorld.Cmd(args...); err != nil {
+		panic(err)
+	}
+}
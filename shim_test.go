@@ -0,0 +1,255 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type panicWorld struct {
+	panicWith any
+	cycles    map[int]func()
+}
+
+func (w *panicWorld) Name() string {
+	return "panic"
+}
+
+func (w *panicWorld) Reset() {}
+
+func (w *panicWorld) Tick() {}
+
+func (w *panicWorld) NewActor(_ ...any) (int, []*ActionInterface) {
+	if w.panicWith != nil {
+		panic(w.panicWith)
+	}
+
+	return 1, nil
+}
+
+func (w *panicWorld) Register(actorId int, cycle func()) {
+	if w.panicWith != nil {
+		panic(w.panicWith)
+	}
+
+	if w.cycles == nil {
+		w.cycles = map[int]func(){}
+	}
+	w.cycles[actorId] = cycle
+}
+
+func (w *panicWorld) Look(_ int) []*Image {
+	return nil
+}
+
+func (w *panicWorld) Feel(_ int) []*Touch {
+	return nil
+}
+
+func (w *panicWorld) Cmd(_ ...any) {
+	if w.panicWith != nil {
+		panic(w.panicWith)
+	}
+}
+
+func TestRecover(t *testing.T) {
+	assert.Nil(t, Recover(nil))
+	assert.Nil(t, Must(nil))
+
+	pw := &panicWorld{}
+	sw := Recover(pw)
+	assert.Equal(t, pw, Must(sw))
+
+	id, _, err := sw.NewActor()
+	assert.Equal(t, 1, id)
+	assert.NoError(t, err)
+	actions, err := sw.Actions(id)
+	assert.NoError(t, err)
+	assert.Empty(t, actions)
+	_, err = sw.Actions(id + 1)
+	assert.ErrorIs(t, err, ErrActorNotFound)
+	_, err = sw.Register(id, func() {})
+	assert.NoError(t, err)
+	assert.NoError(t, sw.Cmd())
+
+	pw.panicWith = ErrActorNotFound
+	_, _, err = sw.NewActor()
+	assert.ErrorIs(t, err, ErrActorNotFound)
+	_, err = sw.Register(id, func() {})
+	assert.ErrorIs(t, err, ErrActorNotFound)
+	assert.ErrorIs(t, sw.Cmd(), ErrActorNotFound)
+
+	pw.panicWith = "not an error"
+	assert.ErrorContains(t, sw.Cmd(), "not an error")
+}
+
+type removablePanicWorld struct {
+	panicWorld
+	removed []int
+}
+
+func (w *removablePanicWorld) RemoveActor(actorId int) {
+	if w.panicWith != nil {
+		panic(w.panicWith)
+	}
+
+	w.removed = append(w.removed, actorId)
+}
+
+func TestRecoverRemoveActor(t *testing.T) {
+	assert.ErrorIs(t, Recover(&panicWorld{}).RemoveActor(1), ErrUnsupported)
+
+	rw := &removablePanicWorld{}
+	sw := Recover(rw)
+	var events []LifecycleEvent
+	sw.Lifecycle().Subscribe(func(event LifecycleEvent, actorId int) {
+		events = append(events, event)
+	})
+
+	id, _, _ := sw.NewActor()
+	calls := 0
+	sw.Register(id, func() { calls++ })
+	assert.NoError(t, sw.RemoveActor(id))
+	assert.Equal(t, []int{id}, rw.removed)
+	assert.Equal(t, []LifecycleEvent{ActorSpawned, ActorRemoved}, events)
+
+	rw.cycles[id]()
+	assert.Zero(t, calls)
+
+	rw.panicWith = ErrActorNotFound
+	assert.ErrorIs(t, sw.RemoveActor(id), ErrActorNotFound)
+	assert.Len(t, events, 2)
+}
+
+func TestRecoverCycles(t *testing.T) {
+	pw := &panicWorld{}
+	sw := Recover(pw)
+	var order []string
+	_, err := sw.Register(1, func() { order = append(order, "a") })
+	assert.NoError(t, err)
+	h, err := sw.Register(1, func() { order = append(order, "b") }, CyclePriority(-1))
+	assert.NoError(t, err)
+	_, err = sw.Register(1, func() { order = append(order, "c") })
+	assert.NoError(t, err)
+	assert.Len(t, pw.cycles, 1)
+
+	pw.cycles[1]()
+	assert.Equal(t, []string{"b", "a", "c"}, order)
+
+	order = nil
+	h.Cancel()
+	pw.cycles[1]()
+	assert.Equal(t, []string{"a", "c"}, order)
+
+	sw.Reset()
+	_, err = sw.Register(1, func() {})
+	assert.NoError(t, err)
+	order = nil
+	pw.cycles[1]()
+	assert.Empty(t, order)
+}
+
+func TestMust(t *testing.T) {
+	pw := &panicWorld{panicWith: ErrInvalidArgs}
+	mw := Must(Recover(pw))
+	assert.Equal(t, pw, mw)
+
+	sw := Recover(pw)
+	wrapped := Must(&struct{ SafeWorld }{sw})
+	assert.PanicsWithError(t, ErrInvalidArgs.Error(), func() {
+		wrapped.NewActor()
+	})
+	assert.PanicsWithError(t, ErrInvalidArgs.Error(), func() {
+		wrapped.Register(0, func() {})
+	})
+	assert.PanicsWithError(t, ErrInvalidArgs.Error(), func() {
+		wrapped.Cmd()
+	})
+
+	pw.panicWith = nil
+	assert.NotPanics(t, func() {
+		wrapped.NewActor()
+	})
+}
+
+func TestFacade(t *testing.T) {
+	pw := &panicWorld{panicWith: ErrWorldNotFound}
+	SetWorld(pw)
+	assert.Equal(t, pw, GetWorld())
+
+	_, _, err := TryNewActor()
+	assert.ErrorIs(t, err, ErrWorldNotFound)
+	_, err = TryRegister(0, func() {})
+	assert.ErrorIs(t, err, ErrWorldNotFound)
+	assert.ErrorIs(t, TryCmd(), ErrWorldNotFound)
+	assert.PanicsWithError(t, ErrWorldNotFound.Error(), func() {
+		NewActor()
+	})
+}
+
+func TestRecoverClock(t *testing.T) {
+	w := Recover(&panicWorld{})
+	w.Tick()
+	w.Tick()
+	assert.Equal(t, 2, w.Clock())
+	w.Reset()
+	assert.Zero(t, w.Clock())
+}
+
+type snapshotPanicWorld struct {
+	panicWorld
+	state int
+}
+
+func (w *snapshotPanicWorld) Snapshot() *Snapshot {
+	if w.panicWith != nil {
+		panic(w.panicWith)
+	}
+
+	return &Snapshot{World: w.Name(), State: w.state}
+}
+
+func (w *snapshotPanicWorld) Restore(snapshot *Snapshot) {
+	w.state = snapshot.State.(int)
+}
+
+func TestRecoverSnapshot(t *testing.T) {
+	_, err := Recover(&panicWorld{}).Snapshot()
+	assert.ErrorIs(t, err, ErrUnsupported)
+	assert.ErrorIs(t, Recover(&panicWorld{}).Restore(&Snapshot{}), ErrUnsupported)
+
+	pw := &snapshotPanicWorld{state: 1}
+	sw := Recover(pw)
+	var events []LifecycleEvent
+	sw.Lifecycle().Subscribe(func(event LifecycleEvent, actorId int) {
+		events = append(events, event)
+	})
+
+	sw.Tick()
+	snapshot, err := sw.Snapshot()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, snapshot.Clock)
+
+	pw.state = 2
+	sw.Tick()
+	id, _, _ := sw.NewActor()
+	calls := 0
+	sw.Register(id, func() { calls++ })
+	assert.NoError(t, sw.Restore(snapshot))
+	assert.Equal(t, 1, pw.state)
+	assert.Equal(t, 1, sw.Clock())
+	assert.Equal(t, []LifecycleEvent{ActorSpawned, ActorRemoved}, events)
+	_, err = sw.Actions(id)
+	assert.ErrorIs(t, err, ErrActorNotFound)
+	pw.cycles[id]()
+	assert.Zero(t, calls)
+
+	assert.ErrorIs(t, sw.Restore(nil), ErrInvalidArgs)
+	assert.ErrorIs(t, sw.Restore(&Snapshot{State: 1}), ErrSnapshotMismatch)
+	other, _ := Recover(&snapshotPanicWorld{}).Snapshot()
+	assert.ErrorIs(t, sw.Restore(other), ErrSnapshotMismatch)
+
+	pw.panicWith = ErrInvalidArgs
+	_, err = sw.Snapshot()
+	assert.ErrorIs(t, err, ErrInvalidArgs)
+}
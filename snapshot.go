@@ -0,0 +1,27 @@
+package world
+
+/*
+Snapshot
+
+	# saved state of a world, taken by SafeWorld.Snapshot and brought back by SafeWorld.Restore
+	# a snapshot may be restored any number of times, i.e. to branch several episodes off the same state or to search from it
+	# a snapshot can only be restored into the world that took it, other worlds return ErrSnapshotMismatch
+
+	# what is restored:
+		# the world's units and their state, actors included, together with the clock
+		# action interfaces an actor held at the time of the snapshot remain valid after restoring
+		# cycle functions are not world state, those of actors that no longer exist after restoring are dropped
+		# actors removed by restoring emit ActorRemoved, actors brought back emit ActorSpawned
+
+	# fields:
+		# World: name of the world that took the snapshot
+		# Clock: the world clock at the time of the snapshot
+		# UnitId: last unit id handed out by the world's session, 0 if the world does not allocate unit ids
+		# State: world specific state, opaque to callers
+*/
+type Snapshot struct {
+	World  string
+	Clock  int
+	UnitId int
+	State  any
+}
@@ -0,0 +1,185 @@
+package world
+
+import (
+	"sort"
+	"sync"
+)
+
+const defaultStreamBuffer = 16
+
+/*
+Observation
+
+	# everything an actor perceived at the end of a tick
+
+	# fields:
+		# Tick: the world clock
+		# ActorId: the observing actor
+		# Images: result of Look
+		# Touches: result of Feel, subscribed actors receive their touches here instead of polling Feel
+*/
+type Observation struct {
+	Tick    int
+	ActorId int
+	Images  []*Image
+	Touches []*Touch
+}
+
+/*
+Subscription
+
+	# a stream of observations of one actor, C is closed once the subscription ends
+	# when the reader falls behind the oldest buffered observation is dropped
+*/
+type Subscription struct {
+	C       <-chan *Observation
+	c       chan *Observation
+	actorId int
+	w       *StreamWorld
+	closed  bool
+}
+
+// Cancel ends the subscription and closes C, returns false if it had already ended
+func (s *Subscription) Cancel() bool {
+	s.w.mu.Lock()
+	defer s.w.mu.Unlock()
+	return s.w.cancel(s)
+}
+
+/*
+StreamWorld
+
+	# wraps any SafeWorld, publishing an observation to every subscription of an actor after each Tick
+	# subscriptions are closed when their actor is removed or the world is reset
+*/
+type StreamWorld struct {
+	SafeWorld
+	mu     sync.Mutex
+	buffer int
+	subs   map[int][]*Subscription
+}
+
+// Stream wraps w, buffer is the number of observations kept per subscription, defaulting to 16
+func Stream(w SafeWorld, buffer ...int) *StreamWorld {
+	result := &StreamWorld{
+		SafeWorld: w,
+		buffer:    defaultStreamBuffer,
+		subs:      map[int][]*Subscription{},
+	}
+
+	if len(buffer) > 0 && buffer[0] > 0 {
+		result.buffer = buffer[0]
+	}
+
+	if lifecycle := w.Lifecycle(); lifecycle != nil {
+		lifecycle.Subscribe(func(event LifecycleEvent, actorId int) {
+			if event == ActorRemoved {
+				result.closeActor(actorId)
+			}
+		})
+	}
+
+	return result
+}
+
+func (w *StreamWorld) Unwrap() SafeWorld {
+	return w.SafeWorld
+}
+
+func (w *StreamWorld) Subscribe(actorId int) (*Subscription, error) {
+	if _, err := w.SafeWorld.Actions(actorId); err != nil {
+		return nil, err
+	}
+
+	c := make(chan *Observation, w.buffer)
+	result := &Subscription{C: c, c: c, actorId: actorId, w: w}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.subs[actorId] = append(w.subs[actorId], result)
+	return result, nil
+}
+
+// Tick advances the wrapped world, then publishes observations in actor id order
+func (w *StreamWorld) Tick() {
+	w.SafeWorld.Tick()
+
+	clock := w.SafeWorld.Clock()
+	w.mu.Lock()
+	var actorIds []int
+	for actorId := range w.subs {
+		actorIds = append(actorIds, actorId)
+	}
+	w.mu.Unlock()
+
+	sort.Ints(actorIds)
+	for _, actorId := range actorIds {
+		w.publish(&Observation{
+			Tick:    clock,
+			ActorId: actorId,
+			Images:  w.SafeWorld.Look(actorId),
+			Touches: w.SafeWorld.Feel(actorId),
+		})
+	}
+}
+
+func (w *StreamWorld) publish(obs *Observation) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, sub := range w.subs[obs.ActorId] {
+		select {
+		case sub.c <- obs:
+		default:
+			select {
+			case <-sub.c:
+			default:
+			}
+			sub.c <- obs
+		}
+	}
+}
+
+func (w *StreamWorld) Reset() {
+	w.SafeWorld.Reset()
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, subs := range w.subs {
+		for _, sub := range subs {
+			w.cancel(sub)
+		}
+	}
+}
+
+func (w *StreamWorld) closeActor(actorId int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, sub := range w.subs[actorId] {
+		w.cancel(sub)
+	}
+}
+
+// cancel must be called with mu held
+func (w *StreamWorld) cancel(sub *Subscription) bool {
+	if sub.closed {
+		return false
+	}
+
+	sub.closed = true
+	close(sub.c)
+	subs := w.subs[sub.actorId]
+	for i, s := range subs {
+		if s == sub {
+			subs = append(subs[:i:i], subs[i+1:]...)
+			break
+		}
+	}
+
+	if len(subs) == 0 {
+		delete(w.subs, sub.actorId)
+	} else {
+		w.subs[sub.actorId] = subs
+	}
+
+	return true
+}
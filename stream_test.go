@@ -0,0 +1,81 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStream(t *testing.T) {
+	lw := &lookWorld{images: []*Image{deltaTestImage(1, 0)}}
+	w := Stream(Recover(lw), 2)
+	actorId, _, _ := w.NewActor()
+
+	_, err := w.Subscribe(actorId + 1)
+	assert.ErrorIs(t, err, ErrActorNotFound)
+
+	sub, err := w.Subscribe(actorId)
+	assert.NoError(t, err)
+	w.Tick()
+	obs := <-sub.C
+	assert.Equal(t, 1, obs.Tick)
+	assert.Equal(t, actorId, obs.ActorId)
+	assert.Equal(t, lw.images, obs.Images)
+
+	// a slow reader loses the oldest observations
+	for i := 0; i < 4; i++ {
+		w.Tick()
+	}
+	assert.Equal(t, 4, (<-sub.C).Tick)
+	assert.Equal(t, 5, (<-sub.C).Tick)
+
+	assert.True(t, sub.Cancel())
+	assert.False(t, sub.Cancel())
+	_, open := <-sub.C
+	assert.False(t, open)
+	w.Tick()
+}
+
+func TestStreamClose(t *testing.T) {
+	w := Stream(Recover(&removablePanicWorld{}))
+	actorId, _, _ := w.NewActor()
+	sub1, _ := w.Subscribe(actorId)
+	sub2, _ := w.Subscribe(actorId)
+
+	assert.NoError(t, w.RemoveActor(actorId))
+	_, open := <-sub1.C
+	assert.False(t, open)
+	_, open = <-sub2.C
+	assert.False(t, open)
+
+	actorId, _, _ = w.NewActor()
+	sub, _ := w.Subscribe(actorId)
+	w.Tick()
+	w.Reset()
+	assert.Equal(t, 1, (<-sub.C).Tick)
+	_, open = <-sub.C
+	assert.False(t, open)
+	assert.False(t, sub.Cancel())
+}
+
+func TestSessionSubscribe(t *testing.T) {
+	s := NewSession()
+	s.SetWorld(&panicWorld{})
+	_, err := s.Subscribe(1)
+	assert.ErrorIs(t, err, ErrUnsupported)
+
+	s.SetSafeWorld(Stream(Recover(&panicWorld{})))
+	actorId, _ := s.NewActor()
+	sub, err := s.Subscribe(actorId)
+	assert.NoError(t, err)
+	s.Tick()
+	assert.Equal(t, 1, (<-sub.C).Tick)
+
+	// a stream behind another wrapper is found through Unwrap
+	s.SetSafeWorld(Debug(Stream(Recover(&panicWorld{})), NewVocabulary("test"), func(err error) {}))
+	actorId, _ = s.NewActor()
+	sub, err = s.Subscribe(actorId)
+	assert.NoError(t, err)
+	s.Tick()
+	assert.Equal(t, 1, (<-sub.C).Tick)
+}
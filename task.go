@@ -0,0 +1,227 @@
+package world
+
+import (
+	"sort"
+	"sync"
+)
+
+/*
+Task
+
+	# a goal set for the actors of a world, attached to any SafeWorld with Attach
+	# tasks are stateless, the state of an episode is kept by the TaskWorld they are attached to
+
+	# methods:
+		# Name: identifies the task, i.e. in logs of a training run
+		# Reset: prepares the world for a new episode, i.e. creating the files the task is about
+			# returns ErrUnsupported if the task cannot be set for the world
+		# Evaluate: scores an actor after a tick, reporting its reward for that tick and whether its episode ended
+*/
+type Task interface {
+	Name() string
+	Reset(w SafeWorld) error
+	Evaluate(w SafeWorld, actorId int) TaskStatus
+}
+
+/*
+TaskStatus
+
+	# the result of evaluating a task for one actor
+
+	# fields:
+		# Reward: reward earned during the tick
+		# Return: sum of the rewards earned since the episode started, filled in by the TaskWorld
+		# Success: whether the actor accomplished the task
+		# Terminated: the episode ended by reaching a terminal state, i.e. the task was accomplished or failed for good
+		# Truncated: the episode was cut short, i.e. by the tick limit of the TaskWorld
+*/
+type TaskStatus struct {
+	Reward     float64
+	Return     float64
+	Success    bool
+	Terminated bool
+	Truncated  bool
+}
+
+func (s TaskStatus) Done() bool {
+	return s.Terminated || s.Truncated
+}
+
+// TaskOption customizes a TaskWorld at Attach time
+type TaskOption func(w *TaskWorld)
+
+// MaxTicks truncates the episode of every actor not done after the given number of ticks, 0 for no limit
+func MaxTicks(ticks int) TaskOption {
+	return func(w *TaskWorld) {
+		w.maxTicks = ticks
+	}
+}
+
+/*
+TaskWorld
+
+	# wraps any SafeWorld, evaluating its task for every actor after each Tick
+	# actors are scored from the time they are spawned, actors spawned before Attach are not scored
+	# the status of an actor stops changing once it is done, Reset starts a new episode for everyone
+
+	# fields:
+		# statuses: actorId -> status of the actor after the last tick
+*/
+type TaskWorld struct {
+	SafeWorld
+	task     Task
+	maxTicks int
+	mu       sync.Mutex
+	statuses map[int]TaskStatus
+}
+
+// Attach sets the task for w, resetting the task right away
+func Attach(w SafeWorld, task Task, opts ...TaskOption) (*TaskWorld, error) {
+	if w == nil || task == nil {
+		return nil, ErrInvalidArgs
+	}
+
+	result := &TaskWorld{
+		SafeWorld: w,
+		task:      task,
+		statuses:  map[int]TaskStatus{},
+	}
+
+	for _, opt := range opts {
+		opt(result)
+	}
+
+	if err := task.Reset(w); err != nil {
+		return nil, err
+	}
+
+	if lifecycle := w.Lifecycle(); lifecycle != nil {
+		lifecycle.Subscribe(func(event LifecycleEvent, actorId int) {
+			result.mu.Lock()
+			defer result.mu.Unlock()
+			if event == ActorSpawned {
+				result.statuses[actorId] = TaskStatus{}
+			} else {
+				delete(result.statuses, actorId)
+			}
+		})
+	}
+
+	return result, nil
+}
+
+func (w *TaskWorld) Unwrap() SafeWorld {
+	return w.SafeWorld
+}
+
+func (w *TaskWorld) Task() Task {
+	return w.task
+}
+
+// Reset resets the wrapped world, then the task, the error of the task is dropped as Attach already validated it
+func (w *TaskWorld) Reset() {
+	w.SafeWorld.Reset()
+	w.mu.Lock()
+	w.statuses = map[int]TaskStatus{}
+	w.mu.Unlock()
+	_ = w.task.Reset(w.SafeWorld)
+}
+
+// Tick ticks the wrapped world, then evaluates the task for every actor not yet done in id order
+func (w *TaskWorld) Tick() {
+	w.SafeWorld.Tick()
+	w.mu.Lock()
+	var actorIds []int
+	for actorId, status := range w.statuses {
+		if !status.Done() {
+			actorIds = append(actorIds, actorId)
+		}
+	}
+	w.mu.Unlock()
+
+	sort.Ints(actorIds)
+	truncated := w.maxTicks > 0 && w.SafeWorld.Clock() >= w.maxTicks
+	for _, actorId := range actorIds {
+		status := w.task.Evaluate(w.SafeWorld, actorId)
+		status.Truncated = status.Truncated || (truncated && !status.Terminated)
+
+		w.mu.Lock()
+		if previous, seen := w.statuses[actorId]; seen {
+			status.Return = previous.Return + status.Reward
+			w.statuses[actorId] = status
+		}
+		w.mu.Unlock()
+	}
+}
+
+// Status returns the status of the actor after the last tick
+func (w *TaskWorld) Status(actorId int) (TaskStatus, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	status, seen := w.statuses[actorId]
+	if !seen {
+		return TaskStatus{}, ErrActorNotFound
+	}
+
+	return status, nil
+}
+
+// Done reports whether the episode of every scored actor ended, false if there are no actors
+func (w *TaskWorld) Done() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, status := range w.statuses {
+		if !status.Done() {
+			return false
+		}
+	}
+
+	return len(w.statuses) > 0
+}
+
+// taskState wraps the snapshot of the wrapped world with the statuses of the actors
+type taskState struct {
+	owner    *TaskWorld
+	inner    *Snapshot
+	statuses map[int]TaskStatus
+}
+
+func (w *TaskWorld) Snapshot() (*Snapshot, error) {
+	inner, err := w.SafeWorld.Snapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	state := &taskState{owner: w, inner: inner, statuses: map[int]TaskStatus{}}
+	for actorId, status := range w.statuses {
+		state.statuses[actorId] = status
+	}
+
+	return &Snapshot{World: inner.World, Clock: inner.Clock, UnitId: inner.UnitId, State: state}, nil
+}
+
+func (w *TaskWorld) Restore(snapshot *Snapshot) error {
+	if snapshot == nil {
+		return ErrInvalidArgs
+	}
+
+	state, ok := snapshot.State.(*taskState)
+	if !ok || state.owner != w {
+		return ErrSnapshotMismatch
+	}
+
+	if err := w.SafeWorld.Restore(state.inner); err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.statuses = map[int]TaskStatus{}
+	for actorId, status := range state.statuses {
+		w.statuses[actorId] = status
+	}
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// clockTask rewards every tick and terminates once the world clock reaches goal
+type clockTask struct {
+	goal   int
+	resets int
+	err    error
+}
+
+func (t *clockTask) Name() string {
+	return "clock"
+}
+
+func (t *clockTask) Reset(_ SafeWorld) error {
+	t.resets++
+	return t.err
+}
+
+func (t *clockTask) Evaluate(w SafeWorld, _ int) TaskStatus {
+	if w.Clock() >= t.goal {
+		return TaskStatus{Reward: 1, Success: true, Terminated: true}
+	}
+
+	return TaskStatus{Reward: 1}
+}
+
+func TestTaskWorld(t *testing.T) {
+	task := &clockTask{goal: 3}
+	w, err := Attach(Recover(&removablePanicWorld{}), task)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, task.resets)
+	assert.Same(t, task, w.Task())
+	assert.False(t, w.Done())
+
+	id, _, _ := w.NewActor()
+	_, err = w.Status(id + 1)
+	assert.ErrorIs(t, err, ErrActorNotFound)
+	for i := 0; i < 2; i++ {
+		w.Tick()
+	}
+
+	status, err := w.Status(id)
+	assert.NoError(t, err)
+	assert.Equal(t, TaskStatus{Reward: 1, Return: 2}, status)
+	assert.False(t, w.Done())
+
+	w.Tick()
+	w.Tick()
+	status, _ = w.Status(id)
+	assert.Equal(t, TaskStatus{Reward: 1, Return: 3, Success: true, Terminated: true}, status)
+	assert.True(t, status.Done())
+	assert.True(t, w.Done())
+
+	assert.NoError(t, w.RemoveActor(id))
+	_, err = w.Status(id)
+	assert.ErrorIs(t, err, ErrActorNotFound)
+
+	w.Reset()
+	assert.Equal(t, 2, task.resets)
+	assert.Same(t, w.SafeWorld, Unwrap(w))
+	assert.Nil(t, Unwrap(w.SafeWorld))
+	assert.Same(t, w.SafeWorld, Unwrap(Stream(w.SafeWorld)))
+}
+
+func TestTaskWorldMaxTicks(t *testing.T) {
+	w, _ := Attach(Recover(&panicWorld{}), &clockTask{goal: 10}, MaxTicks(2))
+	id, _, _ := w.NewActor()
+	w.Tick()
+	status, _ := w.Status(id)
+	assert.False(t, status.Done())
+
+	w.Tick()
+	status, _ = w.Status(id)
+	assert.Equal(t, TaskStatus{Reward: 1, Return: 2, Truncated: true}, status)
+	assert.True(t, w.Done())
+}
+
+func TestTaskWorldAttach(t *testing.T) {
+	_, err := Attach(nil, &clockTask{})
+	assert.ErrorIs(t, err, ErrInvalidArgs)
+	_, err = Attach(Recover(&panicWorld{}), nil)
+	assert.ErrorIs(t, err, ErrInvalidArgs)
+	_, err = Attach(Recover(&panicWorld{}), &clockTask{err: ErrUnsupported})
+	assert.ErrorIs(t, err, ErrUnsupported)
+}
+
+func TestTaskWorldSnapshot(t *testing.T) {
+	w, _ := Attach(Recover(&snapshotPanicWorld{}), &clockTask{goal: 10})
+	id, _, _ := w.NewActor()
+	w.Tick()
+	snapshot, err := w.Snapshot()
+	assert.NoError(t, err)
+
+	w.Tick()
+	w.Tick()
+	assert.NoError(t, w.Restore(snapshot))
+	status, _ := w.Status(id)
+	assert.Equal(t, 1.0, status.Return)
+	assert.ErrorIs(t, w.Restore(nil), ErrInvalidArgs)
+	assert.ErrorIs(t, w.Restore(&Snapshot{}), ErrSnapshotMismatch)
+}
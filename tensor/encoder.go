@@ -0,0 +1,253 @@
+package tensor
+
+import (
+	world "github.com/sapphire-ai-dev/sapphire-world"
+)
+
+// names of the features not tied to a single label
+const (
+	featureUnknown = "[unknown]"
+	memberOther    = "[other]"
+	memberValue    = "[value]"
+)
+
+// family locates the features of one label family in a vector
+type family struct {
+	presence int            // set when the family has no members, -1 otherwise
+	members  map[string]int // one-hot of the member following the root
+	other    int            // open families only, set for members outside the vocabulary, -1 otherwise
+	value    int            // int-valued families only, the scaled Info.Value, -1 otherwise
+}
+
+/*
+Encoder
+
+	# maps images and touches to fixed-width float vectors and observations to padded matrices
+	# features are laid out from a vocabulary, in declaration order:
+		# families without members get a presence feature
+		# each member gets a one-hot feature, open families get one more for members outside the vocabulary
+		# int-valued families, i.e. the distances of the text world's cursor deltas, get a numeric feature holding Value / scale
+	# labels outside the vocabulary set a single trailing unknown feature, string values are not encoded
+*/
+type Encoder struct {
+	families   map[string]*family
+	names      []string
+	scale      float64
+	maxImages  int
+	maxTouches int
+}
+
+// Option customizes an Encoder at construction time
+type Option func(e *Encoder)
+
+// MaxImages sets the number of image rows of an encoded observation, 64 by default
+func MaxImages(n int) Option {
+	return func(e *Encoder) {
+		e.maxImages = n
+	}
+}
+
+// MaxTouches sets the number of touch rows of an encoded observation, 16 by default
+func MaxTouches(n int) Option {
+	return func(e *Encoder) {
+		e.maxTouches = n
+	}
+}
+
+// Scale sets the divisor of int values, 1 by default, New rejects scales that are not positive
+func Scale(scale float64) Option {
+	return func(e *Encoder) {
+		e.scale = scale
+	}
+}
+
+// New returns ErrInvalidArgs for a scale that is not positive or a negative number of rows
+func New(v *world.Vocabulary, opts ...Option) (*Encoder, error) {
+	result := &Encoder{
+		families:   map[string]*family{},
+		scale:      1,
+		maxImages:  64,
+		maxTouches: 16,
+	}
+
+	for _, spec := range v.Specs {
+		result.families[spec.Root] = result.newFamily(spec)
+	}
+
+	result.feature(featureUnknown)
+	for _, opt := range opts {
+		opt(result)
+	}
+
+	if !(result.scale > 0) || result.maxImages < 0 || result.maxTouches < 0 {
+		return nil, world.ErrInvalidArgs
+	}
+
+	return result, nil
+}
+
+// FromOntology builds an encoder from every registered vocabulary, returning the first conflict between them
+func FromOntology(opts ...Option) (*Encoder, error) {
+	v := world.NewVocabulary("")
+	for _, other := range world.Ontology() {
+		if err := v.Merge(other); err != nil {
+			return nil, err
+		}
+	}
+
+	return New(v, opts...)
+}
+
+func (e *Encoder) newFamily(spec *world.LabelSpec) *family {
+	result := &family{presence: -1, members: map[string]int{}, other: -1, value: -1}
+	if len(spec.Members) == 0 && !spec.OpenMembers {
+		result.presence = e.feature(spec.Root)
+	}
+
+	for _, member := range spec.Members {
+		result.members[member] = e.feature(spec.Root + member)
+	}
+
+	if spec.OpenMembers {
+		result.other = e.feature(spec.Root + memberOther)
+	}
+
+	if spec.ValueType == world.ValueInt {
+		result.value = e.feature(spec.Root + memberValue)
+	}
+
+	return result
+}
+
+func (e *Encoder) feature(name string) int {
+	e.names = append(e.names, name)
+	return len(e.names) - 1
+}
+
+// Width is the length of an encoded image or touch
+func (e *Encoder) Width() int {
+	return len(e.names)
+}
+
+// Names describes every feature, i.e. "[charDirection][neg]" or "[charDirection][value]"
+func (e *Encoder) Names() []string {
+	return append([]string{}, e.names...)
+}
+
+// info adds the features of a single Info to vec
+func (e *Encoder) info(info *world.Info, vec []float64) {
+	unknown := len(e.names) - 1
+	if info == nil || len(info.Labels) < 2 || info.Labels[0] != world.InfoLabelObservable {
+		vec[unknown] = 1
+		return
+	}
+
+	f, seen := e.families[info.Labels[1]]
+	if !seen {
+		vec[unknown] = 1
+		return
+	}
+
+	if f.presence >= 0 {
+		vec[f.presence] = 1
+	}
+
+	if len(info.Labels) > 2 {
+		if i, known := f.members[info.Labels[2]]; known {
+			vec[i] = 1
+		} else if f.other >= 0 {
+			vec[f.other] = 1
+		} else {
+			vec[unknown] = 1
+		}
+	}
+
+	if value, isInt := info.Value.(int); isInt && f.value >= 0 {
+		vec[f.value] = float64(value) / e.scale
+	}
+}
+
+func (e *Encoder) Image(img *world.Image) []float64 {
+	result := make([]float64, e.Width())
+	for _, info := range img.Permanent {
+		e.info(info, result)
+	}
+
+	for _, info := range img.Transient {
+		e.info(info, result)
+	}
+
+	return result
+}
+
+func (e *Encoder) Touch(touch *world.Touch) []float64 {
+	result := make([]float64, e.Width())
+	e.info(touch.Info, result)
+	return result
+}
+
+/*
+Tensor
+
+	# an encoded observation of fixed shape
+
+	# fields:
+		# Images: MaxImages rows of Width features, the first images of the observation followed by zero rows
+		# ImageMask: 1 for the rows of Images holding an image, 0 for padding
+		# Touches: MaxTouches rows of Width features, laid out like Images
+		# TouchMask: 1 for the rows of Touches holding a touch, 0 for padding
+*/
+type Tensor struct {
+	Images    [][]float64
+	ImageMask []float64
+	Touches   [][]float64
+	TouchMask []float64
+}
+
+// Flat concatenates the rows of Images, ImageMask, the rows of Touches and TouchMask
+func (t *Tensor) Flat() []float64 {
+	var result []float64
+	for _, row := range t.Images {
+		result = append(result, row...)
+	}
+
+	result = append(result, t.ImageMask...)
+	for _, row := range t.Touches {
+		result = append(result, row...)
+	}
+
+	return append(result, t.TouchMask...)
+}
+
+// Observation encodes obs, images and touches beyond MaxImages and MaxTouches are dropped
+func (e *Encoder) Observation(obs *world.Observation) *Tensor {
+	result := &Tensor{
+		Images:    make([][]float64, e.maxImages),
+		ImageMask: make([]float64, e.maxImages),
+		Touches:   make([][]float64, e.maxTouches),
+		TouchMask: make([]float64, e.maxTouches),
+	}
+
+	for i := range result.Images {
+		if i < len(obs.Images) {
+			result.Images[i], result.ImageMask[i] = e.Image(obs.Images[i]), 1
+		} else {
+			result.Images[i] = make([]float64, e.Width())
+		}
+	}
+
+	for i := range result.Touches {
+		if i < len(obs.Touches) {
+			result.Touches[i], result.TouchMask[i] = e.Touch(obs.Touches[i]), 1
+		} else {
+			result.Touches[i] = make([]float64, e.Width())
+		}
+	}
+
+	return result
+}
+
+// Encode is Observation shaped as a gym.Encoder, i.e. gym.WithEncoder(e.Encode)
+func (e *Encoder) Encode(obs *world.Observation) any {
+	return e.Observation(obs)
+}
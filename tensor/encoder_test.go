@@ -0,0 +1,131 @@
+package tensor
+
+import (
+	"math"
+	"testing"
+
+	world "github.com/sapphire-ai-dev/sapphire-world"
+	"github.com/sapphire-ai-dev/sapphire-world/gym"
+	"github.com/sapphire-ai-dev/sapphire-world/text"
+	"github.com/stretchr/testify/assert"
+)
+
+func testVocabulary() *world.Vocabulary {
+	v := world.NewVocabulary("test")
+	_ = v.Declare(&world.LabelSpec{Root: "[shape]", Members: []string{"a"}, OpenMembers: true})
+	_ = v.Declare(&world.LabelSpec{Root: "[dir]", Members: world.Ternary, ValueType: world.ValueInt})
+	return v
+}
+
+func info(value any, labels ...string) *world.Info {
+	return &world.Info{Labels: append([]string{world.InfoLabelObservable}, labels...), Value: value}
+}
+
+func TestEncoderLayout(t *testing.T) {
+	e, err := New(testVocabulary())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"[outcome][success]", "[outcome][noop]", "[outcome][rejected]", "[outcome][pending]", "[outcome][queued]",
+		"[clock]", "[clock][value]",
+		"[shape]a", "[shape][other]",
+		"[dir][pos]", "[dir][zro]", "[dir][neg]", "[dir][value]",
+		"[unknown]",
+	}, e.Names())
+	assert.Equal(t, 14, e.Width())
+}
+
+func TestEncoderOptions(t *testing.T) {
+	for _, opt := range []Option{Scale(0), Scale(-1), Scale(math.NaN()), MaxImages(-1), MaxTouches(-1)} {
+		_, err := New(testVocabulary(), opt)
+		assert.ErrorIs(t, err, world.ErrInvalidArgs)
+	}
+
+	_, err := FromOntology(Scale(0))
+	assert.ErrorIs(t, err, world.ErrInvalidArgs)
+}
+
+func TestEncoderImage(t *testing.T) {
+	e, _ := New(testVocabulary(), Scale(2))
+	names := e.Names()
+	features := func(vec []float64) map[string]float64 {
+		result := map[string]float64{}
+		for i, value := range vec {
+			if value != 0 {
+				result[names[i]] = value
+			}
+		}
+
+		return result
+	}
+
+	img := &world.Image{
+		Permanent: []*world.Info{info(nil, "[shape]", "a")},
+		Transient: []*world.Info{info(-3, "[dir]", world.TernaryNeg)},
+	}
+	assert.Equal(t, map[string]float64{"[shape]a": 1, "[dir][neg]": 1, "[dir][value]": -1.5}, features(e.Image(img)))
+
+	img = &world.Image{Permanent: []*world.Info{info(nil, "[shape]", "b"), info(nil, "[color]", "red")}}
+	assert.Equal(t, map[string]float64{"[shape][other]": 1, "[unknown]": 1}, features(e.Image(img)))
+
+	assert.Equal(t, map[string]float64{"[clock]": 1, "[clock][value]": 2}, features(e.Touch(world.ClockTouch(1, 4))))
+	touch := world.Rejected("busy").Touch(1, "act")
+	assert.Equal(t, map[string]float64{"[outcome][rejected]": 1}, features(e.Touch(touch)))
+}
+
+func TestEncoderObservation(t *testing.T) {
+	e, _ := New(testVocabulary(), MaxImages(2), MaxTouches(1))
+	img := &world.Image{Permanent: []*world.Info{info(nil, "[shape]", "a")}}
+	tensor := e.Observation(&world.Observation{Images: []*world.Image{img, img, img}})
+	assert.Equal(t, []float64{1, 1}, tensor.ImageMask)
+	assert.Equal(t, []float64{0}, tensor.TouchMask)
+	assert.Len(t, tensor.Images, 2)
+	assert.Len(t, tensor.Touches, 1)
+	assert.Equal(t, make([]float64, e.Width()), tensor.Touches[0])
+	assert.Len(t, tensor.Flat(), 3*e.Width()+3)
+}
+
+func TestEncoderText(t *testing.T) {
+	e, err := FromOntology(MaxImages(8), MaxTouches(4))
+	assert.NoError(t, err)
+	assert.Contains(t, e.Names(), "[charDirection][value]")
+	assert.Contains(t, e.Names(), "[contentType]a")
+
+	s := world.NewSession()
+	text.InitSession(s)
+	w, err := world.Attach(s.GetSafeWorld(), text.FileContains("notes", "ab"))
+	assert.NoError(t, err)
+	env := gym.New(w, gym.WithEncoder(e.Encode))
+	_, err = env.Reset()
+	assert.NoError(t, err)
+
+	var enter, typeA int
+	for i, choice := range env.Choices() {
+		switch choice.String() {
+		case "text.changeItem.itemEnter":
+			enter = i
+		case "text.pressKey.a":
+			typeA = i
+		}
+	}
+
+	env.Step(enter)
+	env.Step(typeA)
+	obs, _, _, _, err := env.Step(typeA)
+	assert.NoError(t, err)
+
+	// the line, both characters before the cursor and the directory holding the file
+	tensor := obs.(*Tensor)
+	assert.Equal(t, []float64{1, 1, 1, 1, 0, 0, 0, 0}, tensor.ImageMask)
+	index := map[string]int{}
+	for i, name := range e.Names() {
+		index[name] = i
+	}
+
+	assert.Equal(t, 1.0, tensor.Images[0][index["[contentType][line]"]])
+	assert.Equal(t, 1.0, tensor.Images[1][index["[contentType]a"]])
+	assert.Equal(t, 1.0, tensor.Images[1][index["[charDirection][neg]"]])
+	assert.Equal(t, -2.0, tensor.Images[1][index["[charDirection][value]"]])
+	assert.Equal(t, -1.0, tensor.Images[2][index["[charDirection][value]"]])
+	assert.Equal(t, 1.0, tensor.Images[3][index["[itemType][directory]"]])
+	assert.Zero(t, tensor.Images[1][index["[unknown]"]])
+}
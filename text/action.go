@@ -1,92 +1,141 @@
 package text
 
 import (
+	"errors"
+	"fmt"
+
 	world "github.com/sapphire-ai-dev/sapphire-world"
 )
 
-func (w *textWorld) validCursorItem(currDir *directory, pos *actorPos, cmd int) bool {
+const (
+	actionCategoryChangeItem = "changeItem"
+	actionCategoryPressKey   = "pressKey"
+	actionCategorySpecialKey = "specialKey"
+	actionCategoryTypeChar   = "typeChar"
+)
+
+func (w *textWorld) actionId(category, key string) string {
+	if key == "" {
+		return w.Name() + "." + category
+	}
+
+	return w.Name() + "." + category + "." + key
+}
+
+// reasons reported by the Diagnose function of text world actions
+var (
+	ErrItemNotFound      = errors.New("actor is not on an item")
+	ErrNotInFile         = errors.New("actor is not inside a file")
+	ErrFileItemCursor    = errors.New("item cursor inside a file is not on the parent directory")
+	ErrFileItemCmd       = errors.New("only itemEnter is available inside a file")
+	ErrItemCursorInvalid = errors.New("item cursor out of range")
+	ErrItemCursorAtFirst = errors.New("item cursor at first item")
+	ErrItemCursorAtLast  = errors.New("item cursor at last item")
+	ErrLineCursorInvalid = errors.New("cursor line out of range")
+	ErrCharCursorInvalid = errors.New("cursor character out of range")
+	ErrCursorAtLineStart = errors.New("cursor at line start")
+	ErrCursorAtLineEnd   = errors.New("cursor at line end")
+	ErrCursorAtFirstLine = errors.New("cursor at first line")
+	ErrCursorAtLastLine  = errors.New("cursor at last line")
+)
+
+func (w *textWorld) checkCursorItem(currDir *directory, pos *actorPos, cmd int) error {
 	dirSize := len(currDir.content)
 	if currDir.parent() != nil {
 		dirSize++
 	}
 
 	if pos.cursorItem < 0 || pos.cursorItem >= dirSize {
-		return false
+		return ErrItemCursorInvalid
 	}
 
 	if pos.cursorItem == 0 && cmd == changeItemCmdUp {
-		return false
+		return ErrItemCursorAtFirst
 	}
 
-	if pos.cursorItem == len(currDir.content)-1 && cmd == changeItemCmdDown {
-		return false
+	if pos.cursorItem == dirSize-1 && cmd == changeItemCmdDown {
+		return ErrItemCursorAtLast
 	}
 
-	return true
+	return nil
 }
 
-func (w *textWorld) locateItem(actorId int, cmd int) (*actorPos, item) {
+func (w *textWorld) locateItem(actorId int, cmd int) (*actorPos, item, error) {
 	pos, posSeen := w.actors[actorId]
 	if !posSeen {
-		return nil, nil
+		return nil, nil, world.ErrActorNotFound
 	}
 
 	currItem, currItemSeen := w.items[pos.currItemId]
 	if !currItemSeen {
-		return nil, nil
+		return nil, nil, ErrItemNotFound
 	}
 
 	if _, ok := currItem.(*file); ok {
 		if pos.cursorItem != 0 {
-			return nil, nil
+			return nil, nil, ErrFileItemCursor
 		}
 
 		if cmd != changeItemCmdEnter {
-			return nil, nil
+			return nil, nil, ErrFileItemCmd
 		}
 	}
 
-	return pos, currItem
+	return pos, currItem, nil
 }
 
-func (w *textWorld) changeItemReady(actorId, cmd int) bool {
-	pos, currItem := w.locateItem(actorId, cmd)
-	if pos == nil {
-		return false
+func (w *textWorld) changeItemCheck(actorId, cmd int) error {
+	pos, currItem, err := w.locateItem(actorId, cmd)
+	if err != nil {
+		return err
 	}
 
 	if _, ok := currItem.(*file); ok {
-		return true
+		return nil
 	}
 
 	currDir := currItem.(*directory)
-	return w.validCursorItem(currDir, pos, cmd)
+	return w.checkCursorItem(currDir, pos, cmd)
+}
+
+func (w *textWorld) changeItemReady(actorId, cmd int) bool {
+	return w.changeItemCheck(actorId, cmd) == nil
 }
 
-func (w *textWorld) changeItemStep(actorId, cmd int) {
-	pos, currItem := w.locateItem(actorId, cmd)
-	if pos == nil {
-		return
+func (w *textWorld) changeItemStep(actorId, cmd int) *world.Outcome {
+	if err := w.changeItemCheck(actorId, cmd); err != nil {
+		return world.Rejected(err.Error())
 	}
 
+	pos, currItem, _ := w.locateItem(actorId, cmd)
 	if _, ok := currItem.(*file); ok {
-		w.actors[actorId].cursorItem = currItem.parent().id()
-		return
+		// leave the file for its directory, the cursor stays on the file
+		parentDir := currItem.parent().(*directory)
+		cursorItem := 0
+		for i, elem := range parentDir.content {
+			if elem == currItem {
+				cursorItem = i
+			}
+		}
+
+		if parentDir.parent() != nil {
+			cursorItem++
+		}
+
+		pos.currItemId, pos.cursorItem = parentDir.id(), cursorItem
+		return world.Success()
 	}
 
 	currDir := currItem.(*directory)
-	if !w.validCursorItem(currDir, pos, cmd) {
-		return
-	}
 
 	if cmd == changeItemCmdUp {
 		w.actors[actorId].cursorItem--
-		return
+		return world.Success()
 	}
 
 	if cmd == changeItemCmdDown {
 		w.actors[actorId].cursorItem++
-		return
+		return world.Success()
 	}
 
 	cursorItem := pos.cursorItem
@@ -101,6 +150,7 @@ func (w *textWorld) changeItemStep(actorId, cmd int) {
 	}
 
 	w.actors[actorId].cursorItem = 0
+	return world.Success()
 }
 
 func (w *textWorld) changeItemWrap(actorId, cmd int) *world.ActionInterface {
@@ -108,62 +158,105 @@ func (w *textWorld) changeItemWrap(actorId, cmd int) *world.ActionInterface {
 		return nil
 	}
 
-	return &world.ActionInterface{
-		Name: pressKeyCmds[cmd],
+	name := changeItemCmds[cmd]
+	result := &world.ActionInterface{
+		Name:        name,
+		Id:          w.actionId(actionCategoryChangeItem, name),
+		Category:    actionCategoryChangeItem,
+		Description: changeItemDescriptions[cmd],
+		World:       w.Name(),
 		Ready: func() bool {
 			return w.changeItemReady(actorId, cmd)
 		},
-		Step: func() {
-			w.changeItemStep(actorId, cmd)
+		Diagnose: func() error {
+			return w.changeItemCheck(actorId, cmd)
 		},
 	}
+
+	result.Step = func() *world.Outcome {
+		return w.perform(actorId, result, nil, actorId, func() *world.Outcome {
+			return w.changeItemStep(actorId, cmd)
+		})
+	}
+
+	return result
 }
 
-func (w *textWorld) identifyFile(actorId int) (*file, *actorPos) {
+func (w *textWorld) identifyFile(actorId int) (*file, *actorPos, error) {
 	pos, posSeen := w.actors[actorId]
 	if !posSeen {
-		return nil, nil
+		return nil, nil, world.ErrActorNotFound
 	}
 
 	currItem, currItemSeen := w.items[pos.currItemId]
 	if !currItemSeen {
-		return nil, nil
+		return nil, nil, ErrItemNotFound
 	}
 
 	currFile, isFile := currItem.(*file)
 	if !isFile {
-		return nil, nil
+		return nil, nil, ErrNotInFile
 	}
 
 	if pos.cursorLine < 0 || pos.cursorLine >= len(currFile.lines) {
-		return nil, nil
+		return nil, nil, ErrLineCursorInvalid
 	}
 
 	currLine := currFile.lines[pos.cursorLine]
 	if pos.cursorChar < 0 || pos.cursorChar > len(currLine.characters) {
-		return nil, nil
+		return nil, nil, ErrCharCursorInvalid
 	}
 
-	return currFile, pos
+	return currFile, pos, nil
+}
+
+func (w *textWorld) pressKeyCheck(actorId int) error {
+	_, _, err := w.identifyFile(actorId)
+	return err
 }
 
 func (w *textWorld) pressKeyReady(actorId int) bool {
-	currFile, _ := w.identifyFile(actorId)
-	return currFile != nil
+	return w.pressKeyCheck(actorId) == nil
 }
 
-func (w *textWorld) pressKeyStep(actorId, cmd int) {
-	currFile, pos := w.identifyFile(actorId)
-	if currFile == nil {
-		return
+func (w *textWorld) pressKeyStep(actorId, cmd int) *world.Outcome {
+	currFile, pos, err := w.identifyFile(actorId)
+	if err != nil {
+		return world.Rejected(err.Error())
 	}
 
-	if val, seen := pressKeyCmds[cmd]; seen {
-		currLine := currFile.lines[pos.cursorLine]
-		left, right := currLine.characters[:pos.cursorChar], currLine.characters[pos.cursorChar:]
-		currLine.characters = append(append(left, currLine.newCharacter(val)), right...)
-		w.actors[actorId].cursorChar++
+	val, seen := pressKeyCmds[cmd]
+	if !seen {
+		return world.Noop("key has no character")
 	}
+
+	currLine := currFile.lines[pos.cursorLine]
+	characters := make([]*character, 0, len(currLine.characters)+1)
+	characters = append(characters, currLine.characters[:pos.cursorChar]...)
+	characters = append(characters, currLine.newCharacter(val))
+	currLine.characters = append(characters, currLine.characters[pos.cursorChar:]...)
+	currFile.modified()
+	for _, other := range w.otherCursors(actorId, currFile) {
+		if other.cursorLine == pos.cursorLine && other.cursorChar > pos.cursorChar {
+			other.cursorChar++
+		}
+	}
+
+	w.actors[actorId].cursorChar++
+	return world.Success()
+}
+
+// otherCursors returns the cursors of the other actors in the file, so that an edit can keep them on the same text
+// cursors after the edit move with the text, cursors at the edit stay in front of it
+func (w *textWorld) otherCursors(actorId int, f *file) []*actorPos {
+	var result []*actorPos
+	for id, pos := range w.actors {
+		if id != actorId && pos.currItemId == f.id() {
+			result = append(result, pos)
+		}
+	}
+
+	return result
 }
 
 func (w *textWorld) pressKeyWrap(actorId, cmd int) *world.ActionInterface {
@@ -171,75 +264,106 @@ func (w *textWorld) pressKeyWrap(actorId, cmd int) *world.ActionInterface {
 		return nil
 	}
 
-	return &world.ActionInterface{
-		Name: "key" + pressKeyCmds[cmd],
+	name := "key" + pressKeyCmds[cmd]
+	result := &world.ActionInterface{
+		Name:        name,
+		Id:          w.actionId(actionCategoryPressKey, pressKeyIds[cmd]),
+		Category:    actionCategoryPressKey,
+		Description: fmt.Sprintf("type %q at the cursor", pressKeyCmds[cmd]),
+		World:       w.Name(),
 		Ready: func() bool {
 			return w.pressKeyReady(actorId)
 		},
-		Step: func() {
-			w.pressKeyStep(actorId, cmd)
+		Diagnose: func() error {
+			return w.pressKeyCheck(actorId)
 		},
 	}
+
+	result.Step = func() *world.Outcome {
+		return w.perform(actorId, result, nil, w.editKey(actorId), func() *world.Outcome {
+			return w.pressKeyStep(actorId, cmd)
+		})
+	}
+
+	return result
 }
 
-func (w *textWorld) validCursor(actorId int, cmd int) bool {
-	currFile, pos := w.identifyFile(actorId)
-	if currFile == nil {
-		return false
+func (w *textWorld) checkCursor(actorId int, cmd int) error {
+	currFile, pos, err := w.identifyFile(actorId)
+	if err != nil {
+		return err
 	}
 
 	if pos.cursorChar == 0 && (cmd == pressKeyCmdLeft || cmd == pressKeyCmdBackspace) {
-		return false
+		return ErrCursorAtLineStart
 	}
 
 	if pos.cursorLine == 0 && cmd == pressKeyCmdUp {
-		return false
+		return ErrCursorAtFirstLine
 	}
 
 	currLine := currFile.lines[pos.cursorLine]
 	if pos.cursorLine == len(currFile.lines)-1 && cmd == pressKeyCmdDown {
-		return false
+		return ErrCursorAtLastLine
 	}
 
 	if pos.cursorChar == len(currLine.characters) && cmd == pressKeyCmdRight {
-		return false
+		return ErrCursorAtLineEnd
 	}
 
-	return true
+	return nil
 }
 
 func (w *textWorld) specialKeyReady(actorId int, cmd int) bool {
-	return w.validCursor(actorId, cmd)
+	return w.checkCursor(actorId, cmd) == nil
 }
 
-func (w *textWorld) specialKeyStep(actorId int, cmd int) {
-	currFile, pos := w.identifyFile(actorId)
-	if currFile == nil {
-		return
+func (w *textWorld) specialKeyStep(actorId int, cmd int) *world.Outcome {
+	if err := w.checkCursor(actorId, cmd); err != nil {
+		return world.Rejected(err.Error())
 	}
 
-	if !w.validCursor(actorId, cmd) {
-		return
-	}
+	currFile, pos, _ := w.identifyFile(actorId)
 
 	currLine := currFile.lines[pos.cursorLine]
 
 	switch cmd {
+	// edits build new slices, splicing in place would overwrite the elements after the cursor
 	case pressKeyCmdBackspace:
-		w.actors[actorId].cursorChar--
+		characters := make([]*character, 0, len(currLine.characters)-1)
+		characters = append(characters, currLine.characters[:pos.cursorChar-1]...)
+		currLine.characters = append(characters, currLine.characters[pos.cursorChar:]...)
+		currFile.modified()
+		for _, other := range w.otherCursors(actorId, currFile) {
+			if other.cursorLine == pos.cursorLine && other.cursorChar >= pos.cursorChar {
+				other.cursorChar--
+			}
+		}
 
-		left, right := currLine.characters[:pos.cursorChar-1], currLine.characters[pos.cursorChar:]
-		currLine.characters = append(left, right...)
+		pos.cursorChar--
 	case pressKeyCmdEnter:
-		currLine.characters = currLine.characters[:pos.cursorChar]
 		newLine := currFile.newLine()
-		newLine.characters = currLine.characters[pos.cursorChar:]
+		newLine.characters = append([]*character{}, currLine.characters[pos.cursorChar:]...)
+		for _, c := range newLine.characters {
+			c.parent = newLine
+		}
 
-		up, down := currFile.lines[:pos.cursorLine], currFile.lines[pos.cursorLine+1:]
-		up = append(up, currLine)
-		currFile.lines = append(append(up, newLine), down...)
-		w.actors[actorId].cursorLine++
-		w.actors[actorId].cursorChar = 0
+		currLine.characters = append([]*character{}, currLine.characters[:pos.cursorChar]...)
+		lines := make([]*line, 0, len(currFile.lines)+1)
+		lines = append(lines, currFile.lines[:pos.cursorLine+1]...)
+		lines = append(lines, newLine)
+		currFile.lines = append(lines, currFile.lines[pos.cursorLine+1:]...)
+		currFile.modified()
+		for _, other := range w.otherCursors(actorId, currFile) {
+			if other.cursorLine > pos.cursorLine {
+				other.cursorLine++
+			} else if other.cursorLine == pos.cursorLine && other.cursorChar > pos.cursorChar {
+				other.cursorLine, other.cursorChar = other.cursorLine+1, other.cursorChar-pos.cursorChar
+			}
+		}
+
+		pos.cursorLine++
+		pos.cursorChar = 0
 	case pressKeyCmdUp:
 		w.actors[actorId].cursorLine--
 	case pressKeyCmdDown:
@@ -249,6 +373,8 @@ func (w *textWorld) specialKeyStep(actorId int, cmd int) {
 	case pressKeyCmdRight:
 		w.actors[actorId].cursorChar++
 	}
+
+	return world.Success()
 }
 
 func (w *textWorld) specialKeyWrap(actorId, cmd int) *world.ActionInterface {
@@ -256,31 +382,164 @@ func (w *textWorld) specialKeyWrap(actorId, cmd int) *world.ActionInterface {
 		return nil
 	}
 
-	return &world.ActionInterface{
-		Name: "key" + pressKeyCmds[cmd],
+	name := "key" + pressKeyIds[cmd]
+	result := &world.ActionInterface{
+		Name:        name,
+		Id:          w.actionId(actionCategorySpecialKey, pressKeyIds[cmd]),
+		Category:    actionCategorySpecialKey,
+		Description: specialKeyDescriptions[cmd],
+		World:       w.Name(),
 		Ready: func() bool {
 			return w.specialKeyReady(actorId, cmd)
 		},
-		Step: func() {
-			w.specialKeyStep(actorId, cmd)
+		Diagnose: func() error {
+			return w.checkCursor(actorId, cmd)
+		},
+	}
+
+	result.Step = func() *world.Outcome {
+		var key any = actorId
+		if cmd == pressKeyCmdBackspace || cmd == pressKeyCmdEnter {
+			key = w.editKey(actorId)
+		}
+
+		return w.perform(actorId, result, nil, key, func() *world.Outcome {
+			return w.specialKeyStep(actorId, cmd)
+		})
+	}
+
+	return result
+}
+
+var typeCharSchema = world.Schema{world.EnumParam("char", typeableChars()...)}
+
+// typeableChars lists the characters produced by the key commands, in key command order
+func typeableChars() []string {
+	var result []string
+	for cmd := 0; cmd < pressKeyCmdEnd; cmd++ {
+		if val, seen := pressKeyCmds[cmd]; seen {
+			result = append(result, val)
+		}
+	}
+
+	return result
+}
+
+func pressKeyCmdOf(char string) (int, bool) {
+	for cmd, val := range pressKeyCmds {
+		if val == char {
+			return cmd, true
+		}
+	}
+
+	return 0, false
+}
+
+func (w *textWorld) typeCharStep(actorId int, args ...any) *world.Outcome {
+	if err := typeCharSchema.Validate(args); err != nil {
+		return world.Rejected(err.Error())
+	}
+
+	cmd, _ := pressKeyCmdOf(args[0].(string))
+	return w.pressKeyStep(actorId, cmd)
+}
+
+// typeCharWrap is the parameterized counterpart of pressKeyWrap, typing any character in a single action
+func (w *textWorld) typeCharWrap(actorId int) *world.ActionInterface {
+	name := "typeChar"
+	result := &world.ActionInterface{
+		Name:        name,
+		Id:          w.actionId(actionCategoryTypeChar, ""),
+		Category:    actionCategoryTypeChar,
+		Description: "type the given character at the cursor",
+		World:       w.Name(),
+		Ready: func() bool {
+			return w.pressKeyReady(actorId)
+		},
+		Diagnose: func() error {
+			return w.pressKeyCheck(actorId)
+		},
+		Step: func() *world.Outcome {
+			return w.report(actorId, name, w.typeCharStep(actorId))
 		},
+		Schema:    typeCharSchema,
+		Shorthand: true,
 	}
+
+	result.StepWith = func(args ...any) *world.Outcome {
+		if err := typeCharSchema.Validate(args); err != nil {
+			return w.report(actorId, name, world.Rejected(err.Error()))
+		}
+
+		return w.perform(actorId, result, args, w.editKey(actorId), func() *world.Outcome {
+			return w.typeCharStep(actorId, args...)
+		})
+	}
+
+	return result
+}
+
+// report queues the outcome to be felt by the actor, outcomes of unknown actors are dropped
+func (w *textWorld) report(actorId int, name string, outcome *world.Outcome) *world.Outcome {
+	if _, seen := w.actors[actorId]; seen {
+		touch := outcome.Touch(actorId, name)
+		touch.Time = w.clock
+		w.touches[actorId] = append(w.touches[actorId], touch)
+	}
+
+	return outcome
 }
 
+// perform runs the step right away, or queues it as an intent resolved at Tick when the world is in simultaneous mode
+func (w *textWorld) perform(actorId int, action *world.ActionInterface, args []any, key any, step func() *world.Outcome) *world.Outcome {
+	if !w.simultaneous {
+		return w.report(actorId, action.Name, step())
+	}
+
+	if err := action.Diagnose(); err != nil {
+		return w.report(actorId, action.Name, world.Rejected(err.Error()))
+	}
+
+	return w.report(actorId, action.Name, w.intents.Enqueue(actorId, action.Id, action.Name, args, key, func() *world.Outcome {
+		outcome := w.report(actorId, action.Name, step())
+		if outcome.Status != world.OutcomeRejected {
+			w.scheduler.Applied(actorId, action)
+		}
+
+		return outcome
+	}))
+}
+
+// editKey is the conflict key of actions editing text, edits to the same line conflict with each other
+func (w *textWorld) editKey(actorId int) any {
+	currFile, pos, err := w.identifyFile(actorId)
+	if err != nil {
+		return actorId
+	}
+
+	return currFile.lines[pos.cursorLine]
+}
+
+// newActionInterfaces lists the actions in command order, so that every actor and every run sees the same list
 func (w *textWorld) newActionInterfaces(actorId int) []*world.ActionInterface {
 	var result []*world.ActionInterface
-	for cmd := range changeItemCmds {
+	for cmd := 0; cmd < changeItemCmdEnd; cmd++ {
 		result = append(result, w.changeItemWrap(actorId, cmd))
 	}
 
-	for cmd := range pressKeyCmds {
-		result = append(result, w.pressKeyWrap(actorId, cmd))
+	for cmd := 0; cmd < pressKeyCmdEnd; cmd++ {
+		if _, seen := pressKeyCmds[cmd]; seen {
+			result = append(result, w.pressKeyWrap(actorId, cmd))
+		}
 	}
 
-	for cmd := range specialKeyCmds {
-		result = append(result, w.specialKeyWrap(actorId, cmd))
+	for cmd := 0; cmd < pressKeyCmdEnd; cmd++ {
+		if specialKeyCmds[cmd] {
+			result = append(result, w.specialKeyWrap(actorId, cmd))
+		}
 	}
 
+	result = append(result, w.typeCharWrap(actorId))
 	return result
 }
 
@@ -299,6 +558,13 @@ var changeItemCmds = map[int]string{
 	changeItemCmdExec:  "itemExec",
 }
 
+var changeItemDescriptions = map[int]string{
+	changeItemCmdUp:    "move the item cursor to the previous item",
+	changeItemCmdDown:  "move the item cursor to the next item",
+	changeItemCmdEnter: "open the item under the item cursor",
+	changeItemCmdExec:  "execute the item under the item cursor",
+}
+
 const (
 	pressKeyCmd0 = iota
 	pressKeyCmd1
@@ -373,76 +639,158 @@ const (
 )
 
 var pressKeyCmds = map[int]string{
-	pressKeyCmd1: "1",
-	pressKeyCmd2: "2",
-	pressKeyCmd3: "3",
-	pressKeyCmd4: "4",
-	pressKeyCmd5: "5",
-	pressKeyCmd6: "6",
-	pressKeyCmd7: "7",
-	pressKeyCmd8: "8",
-	pressKeyCmd9: "9",
-	pressKeyCmdA: "a",
-	pressKeyCmdB: "b",
-	pressKeyCmdC: "c",
-	pressKeyCmdD: "d",
-	pressKeyCmd0: "0",
-	pressKeyCmdE: "e",
-	pressKeyCmdF: "f",
-	pressKeyCmdG: "g",
-	pressKeyCmdH: "h",
-	pressKeyCmdI: "i",
-	pressKeyCmdJ: "j",
-	pressKeyCmdK: "k",
-	pressKeyCmdL: "l",
-	pressKeyCmdM: "m",
-	pressKeyCmdN: "n",
-	pressKeyCmdO: "o",
-	pressKeyCmdP: "p",
-	pressKeyCmdQ: "q",
-	pressKeyCmdR: "r",
-	pressKeyCmdS: "s",
-	pressKeyCmdT: "t",
-	pressKeyCmdU: "u",
-	pressKeyCmdV: "v",
-	pressKeyCmdW: "w",
-	pressKeyCmdX: "x",
-	pressKeyCmdY: "y",
-	pressKeyCmdZ: "z",
-    pressKeyCmdShift0:             "!",
-    pressKeyCmdShift1:             "@",
-    pressKeyCmdShift2:             "#",
-    pressKeyCmdShift3:             "$",
-    pressKeyCmdShift4:             "%",
-    pressKeyCmdShift5:             "^",
-    pressKeyCmdShift6:             "&",
-    pressKeyCmdShift7:             "*",
-    pressKeyCmdShift8:             "(",
-    pressKeyCmdShift9:             ")",
-    pressKeyCmdMinus:              "-",
-    pressKeyCmdPlus:               "+",
-    pressKeyCmdUnderscore:         "_",
-    pressKeyCmdEqual:              "=",
-    pressKeyCmdLeftSquareBracket:  "[",
-    pressKeyCmdLeftCurlyBracket:   "{",
-    pressKeyCmdRightSquareBracket: "]",
-    pressKeyCmdRightCurlyBracket:  "}",
-    pressKeyCmdSpace:              " ",
-    pressKeyCmdComma:              ",",
-    pressKeyCmdPeriod:             ".",
-    pressKeyCmdSlash:              "/",
-    pressKeyCmdShiftComma:         "<",
-    pressKeyCmdShiftPeriod:        ">",
-    pressKeyCmdShiftSlash:         "?",
-    pressKeyCmdBackSlash:          "\\",
-    pressKeyCmdVertical:           "|",
+	pressKeyCmd1:                  "1",
+	pressKeyCmd2:                  "2",
+	pressKeyCmd3:                  "3",
+	pressKeyCmd4:                  "4",
+	pressKeyCmd5:                  "5",
+	pressKeyCmd6:                  "6",
+	pressKeyCmd7:                  "7",
+	pressKeyCmd8:                  "8",
+	pressKeyCmd9:                  "9",
+	pressKeyCmdA:                  "a",
+	pressKeyCmdB:                  "b",
+	pressKeyCmdC:                  "c",
+	pressKeyCmdD:                  "d",
+	pressKeyCmd0:                  "0",
+	pressKeyCmdE:                  "e",
+	pressKeyCmdF:                  "f",
+	pressKeyCmdG:                  "g",
+	pressKeyCmdH:                  "h",
+	pressKeyCmdI:                  "i",
+	pressKeyCmdJ:                  "j",
+	pressKeyCmdK:                  "k",
+	pressKeyCmdL:                  "l",
+	pressKeyCmdM:                  "m",
+	pressKeyCmdN:                  "n",
+	pressKeyCmdO:                  "o",
+	pressKeyCmdP:                  "p",
+	pressKeyCmdQ:                  "q",
+	pressKeyCmdR:                  "r",
+	pressKeyCmdS:                  "s",
+	pressKeyCmdT:                  "t",
+	pressKeyCmdU:                  "u",
+	pressKeyCmdV:                  "v",
+	pressKeyCmdW:                  "w",
+	pressKeyCmdX:                  "x",
+	pressKeyCmdY:                  "y",
+	pressKeyCmdZ:                  "z",
+	pressKeyCmdShift0:             "!",
+	pressKeyCmdShift1:             "@",
+	pressKeyCmdShift2:             "#",
+	pressKeyCmdShift3:             "$",
+	pressKeyCmdShift4:             "%",
+	pressKeyCmdShift5:             "^",
+	pressKeyCmdShift6:             "&",
+	pressKeyCmdShift7:             "*",
+	pressKeyCmdShift8:             "(",
+	pressKeyCmdShift9:             ")",
+	pressKeyCmdMinus:              "-",
+	pressKeyCmdPlus:               "+",
+	pressKeyCmdUnderscore:         "_",
+	pressKeyCmdEqual:              "=",
+	pressKeyCmdLeftSquareBracket:  "[",
+	pressKeyCmdLeftCurlyBracket:   "{",
+	pressKeyCmdRightSquareBracket: "]",
+	pressKeyCmdRightCurlyBracket:  "}",
+	pressKeyCmdSpace:              " ",
+	pressKeyCmdComma:              ",",
+	pressKeyCmdPeriod:             ".",
+	pressKeyCmdSlash:              "/",
+	pressKeyCmdShiftComma:         "<",
+	pressKeyCmdShiftPeriod:        ">",
+	pressKeyCmdShiftSlash:         "?",
+	pressKeyCmdBackSlash:          "\\",
+	pressKeyCmdVertical:           "|",
+}
+
+// stable identifiers of the key commands, used to build action ids
+var pressKeyIds = map[int]string{
+	pressKeyCmd0:                  "0",
+	pressKeyCmd1:                  "1",
+	pressKeyCmd2:                  "2",
+	pressKeyCmd3:                  "3",
+	pressKeyCmd4:                  "4",
+	pressKeyCmd5:                  "5",
+	pressKeyCmd6:                  "6",
+	pressKeyCmd7:                  "7",
+	pressKeyCmd8:                  "8",
+	pressKeyCmd9:                  "9",
+	pressKeyCmdA:                  "a",
+	pressKeyCmdB:                  "b",
+	pressKeyCmdC:                  "c",
+	pressKeyCmdD:                  "d",
+	pressKeyCmdE:                  "e",
+	pressKeyCmdF:                  "f",
+	pressKeyCmdG:                  "g",
+	pressKeyCmdH:                  "h",
+	pressKeyCmdI:                  "i",
+	pressKeyCmdJ:                  "j",
+	pressKeyCmdK:                  "k",
+	pressKeyCmdL:                  "l",
+	pressKeyCmdM:                  "m",
+	pressKeyCmdN:                  "n",
+	pressKeyCmdO:                  "o",
+	pressKeyCmdP:                  "p",
+	pressKeyCmdQ:                  "q",
+	pressKeyCmdR:                  "r",
+	pressKeyCmdS:                  "s",
+	pressKeyCmdT:                  "t",
+	pressKeyCmdU:                  "u",
+	pressKeyCmdV:                  "v",
+	pressKeyCmdW:                  "w",
+	pressKeyCmdX:                  "x",
+	pressKeyCmdY:                  "y",
+	pressKeyCmdZ:                  "z",
+	pressKeyCmdShift0:             "shift0",
+	pressKeyCmdShift1:             "shift1",
+	pressKeyCmdShift2:             "shift2",
+	pressKeyCmdShift3:             "shift3",
+	pressKeyCmdShift4:             "shift4",
+	pressKeyCmdShift5:             "shift5",
+	pressKeyCmdShift6:             "shift6",
+	pressKeyCmdShift7:             "shift7",
+	pressKeyCmdShift8:             "shift8",
+	pressKeyCmdShift9:             "shift9",
+	pressKeyCmdMinus:              "minus",
+	pressKeyCmdPlus:               "plus",
+	pressKeyCmdUnderscore:         "underscore",
+	pressKeyCmdEqual:              "equal",
+	pressKeyCmdLeftSquareBracket:  "leftSquareBracket",
+	pressKeyCmdLeftCurlyBracket:   "leftCurlyBracket",
+	pressKeyCmdRightSquareBracket: "rightSquareBracket",
+	pressKeyCmdRightCurlyBracket:  "rightCurlyBracket",
+	pressKeyCmdSpace:              "space",
+	pressKeyCmdComma:              "comma",
+	pressKeyCmdPeriod:             "period",
+	pressKeyCmdSlash:              "slash",
+	pressKeyCmdShiftComma:         "shiftComma",
+	pressKeyCmdShiftPeriod:        "shiftPeriod",
+	pressKeyCmdShiftSlash:         "shiftSlash",
+	pressKeyCmdBackSlash:          "backSlash",
+	pressKeyCmdVertical:           "vertical",
+	pressKeyCmdBackspace:          "backspace",
+	pressKeyCmdEnter:              "enter",
+	pressKeyCmdUp:                 "up",
+	pressKeyCmdDown:               "down",
+	pressKeyCmdLeft:               "left",
+	pressKeyCmdRight:              "right",
+}
+
+var specialKeyDescriptions = map[int]string{
+	pressKeyCmdBackspace: "delete the character before the cursor",
+	pressKeyCmdEnter:     "split the line at the cursor",
+	pressKeyCmdUp:        "move the cursor to the previous line",
+	pressKeyCmdDown:      "move the cursor to the next line",
+	pressKeyCmdLeft:      "move the cursor to the previous character",
+	pressKeyCmdRight:     "move the cursor to the next character",
 }
 
 var specialKeyCmds = map[int]bool{
-    pressKeyCmdBackspace: true,
-    pressKeyCmdEnter:     true,
-    pressKeyCmdUp:        true,
-    pressKeyCmdDown:      true,
-    pressKeyCmdLeft:      true,
-    pressKeyCmdRight:     true,
+	pressKeyCmdBackspace: true,
+	pressKeyCmdEnter:     true,
+	pressKeyCmdUp:        true,
+	pressKeyCmdDown:      true,
+	pressKeyCmdLeft:      true,
+	pressKeyCmdRight:     true,
 }
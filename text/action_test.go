@@ -17,7 +17,7 @@ func TestChangeItemErrorHandling(t *testing.T) {
 	c1.Step()
 
 	// cannot go up
-	actorId, _ := w.NewActor()
+	actorId, _, _ := w.NewActor()
 	c2 := w.changeItemWrap(actorId, changeItemCmdUp)
 	assert.False(t, c2.Ready())
 	c2.Step()
@@ -44,7 +44,7 @@ func assertItemDirections(t *testing.T, w *textWorld, actorId int, itemDirection
 func TestChangeItemUpDown(t *testing.T) {
 	w := newTextWorld()
 	root := w.rootDirectory
-	actorId, _ := w.NewActor()
+	actorId, _, _ := w.NewActor()
 	f1, f2, f3 := root.newFile("fName1"), root.newFile("fName2"), root.newFile("fName3")
 	ciU := w.changeItemWrap(actorId, changeItemCmdUp)
 	ciD := w.changeItemWrap(actorId, changeItemCmdDown)
@@ -92,9 +92,29 @@ func TestChangeItemUpDown(t *testing.T) {
 	assertPos1()
 }
 
+func TestChangeItemUpDownSubdirectory(t *testing.T) {
+	w := newTextWorld()
+	actorId, _, _ := w.NewActor()
+	d := w.rootDirectory.newDirectory("dName")
+	_, f2 := d.newFile("fName1"), d.newFile("fName2")
+	ciD := w.changeItemWrap(actorId, changeItemCmdDown)
+	ciE := w.changeItemWrap(actorId, changeItemCmdEnter)
+	ciE.Step()
+
+	// the cursor starts on the parent directory, followed by both files
+	assert.Equal(t, world.OutcomeSuccess, ciD.Step().Status)
+	assert.Equal(t, world.OutcomeSuccess, ciD.Step().Status)
+	assert.Equal(t, 2, w.actors[actorId].cursorItem)
+	assert.False(t, ciD.Ready())
+	assert.Equal(t, ErrItemCursorAtLast.Error(), ciD.Step().Reason)
+
+	ciE.Step()
+	assert.Equal(t, f2.id(), w.actors[actorId].currItemId)
+}
+
 func TestChangeItemEnter(t *testing.T) {
 	w := newTextWorld()
-	actorId, _ := w.NewActor()
+	actorId, _, _ := w.NewActor()
 	c1 := w.changeItemWrap(actorId, changeItemCmdEnter)
 	assert.False(t, c1.Ready())
 	c1.Step()
@@ -120,9 +140,12 @@ func TestChangeItemEnter(t *testing.T) {
 	c1.Step()
 	assert.Equal(t, f.id(), w.actors[actorId].currItemId)
 
-	// enter parent directory from file
+	// enter parent directory from file, the cursor stays on the file
 	assert.True(t, c1.Ready())
 	c1.Step()
+	assert.Equal(t, d.id(), w.actors[actorId].currItemId)
+	assert.Equal(t, 1, w.actors[actorId].cursorItem)
+	c1.Step()
 	assert.Equal(t, f.id(), w.actors[actorId].currItemId)
 
 	// enter other position from file should fail
@@ -152,7 +175,7 @@ func TestPressKeyErrorHandling(t *testing.T) {
 	c1.Step()
 
 	// not on a file
-	actorId, _ := w.NewActor()
+	actorId, _, _ := w.NewActor()
 	c2 := w.pressKeyWrap(actorId, pressKeyCmd0)
 	assert.False(t, c2.Ready())
 	c2.Step()
@@ -192,7 +215,7 @@ func TestPressKeyErrorHandling(t *testing.T) {
 
 func TestPressKey(t *testing.T) {
 	w := newTextWorld()
-	actorId, _ := w.NewActor()
+	actorId, _, _ := w.NewActor()
 	root := w.rootDirectory
 	f := root.newFile("fName")
 	c0 := w.pressKeyWrap(actorId, pressKeyCmd0)
@@ -209,7 +232,7 @@ func TestPressKey(t *testing.T) {
 
 func TestSpecialKey(t *testing.T) {
 	w := newTextWorld()
-	actorId, _ := w.NewActor()
+	actorId, _, _ := w.NewActor()
 
 	root := w.rootDirectory
 	f := root.newFile("fName")
@@ -266,3 +289,179 @@ func TestSpecialKey(t *testing.T) {
     assert.False(t, ciD.Ready())
     ciD.Step()
 }
+
+func TestActionOutcome(t *testing.T) {
+	w := newTextWorld()
+	actorId, _, _ := w.NewActor()
+	root := w.rootDirectory
+	f := root.newFile("fName")
+
+	// rejected actions are reported through the returned outcome and the next Feel
+	c0 := w.pressKeyWrap(actorId, pressKeyCmd0)
+	o := c0.Step()
+	assert.Equal(t, world.OutcomeRejected, o.Status)
+	assert.NotEmpty(t, o.Reason)
+
+	ciD := w.changeItemWrap(actorId, changeItemCmdDown)
+	assert.Equal(t, world.OutcomeRejected, ciD.Step().Status)
+
+	ciE := w.changeItemWrap(actorId, changeItemCmdEnter)
+	assert.Equal(t, world.OutcomeSuccess, ciE.Step().Status)
+	assert.Equal(t, f.id(), w.actors[actorId].currItemId)
+
+	tchs := w.Feel(actorId)
+	assert.Len(t, tchs, 3)
+	assert.Equal(t, c0.Name, tchs[0].Name)
+	assert.Equal(t, actorId, tchs[0].Id)
+	assert.Contains(t, tchs[0].Info.Labels, world.InfoLabelOutcome)
+	assert.Contains(t, tchs[0].Info.Labels, world.OutcomeRejected.Label())
+	assert.Contains(t, tchs[2].Info.Labels, world.OutcomeSuccess.Label())
+	assertEmptyNotNil(t, w.Feel(actorId))
+
+	// successful keystrokes
+	assert.Equal(t, world.OutcomeSuccess, c0.Step().Status)
+	ciL := w.specialKeyWrap(actorId, pressKeyCmdLeft)
+	assert.Equal(t, world.OutcomeSuccess, ciL.Step().Status)
+	assert.Equal(t, world.OutcomeRejected, ciL.Step().Status)
+	assert.Len(t, w.Feel(actorId), 3)
+
+	// entering from a file leaves it for its directory, with the item cursor on the file
+	assert.Equal(t, world.OutcomeSuccess, ciE.Step().Status)
+	assert.Equal(t, root.id(), w.actors[actorId].currItemId)
+	assert.Equal(t, world.OutcomeSuccess, ciE.Step().Status)
+	assert.Equal(t, f.id(), w.actors[actorId].currItemId)
+
+	// outcomes of unknown actors are not felt
+	ghost := w.pressKeyWrap(0, pressKeyCmd0)
+	assert.Equal(t, world.OutcomeRejected, ghost.Step().Status)
+	assertEmptyNotNil(t, w.Feel(0))
+}
+
+func TestTypeChar(t *testing.T) {
+	w := newTextWorld()
+	actorId, actions, _ := w.NewActor()
+	var typeChar *world.ActionInterface
+	for _, action := range actions {
+		if action.Parameterized() {
+			typeChar = action
+		}
+	}
+	assert.NotNil(t, typeChar)
+	assert.Len(t, typeChar.Schema, 1)
+	assert.Len(t, typeChar.Schema[0].Values, len(pressKeyCmds))
+	assert.False(t, typeChar.Ready())
+	assert.Equal(t, world.OutcomeRejected, typeChar.StepWith("a").Status)
+
+	f := w.rootDirectory.newFile("fName")
+	w.actors[actorId].currItemId = f.id()
+	assert.True(t, typeChar.Ready())
+	assert.Equal(t, world.OutcomeSuccess, typeChar.StepWith("a").Status)
+	assert.Equal(t, world.OutcomeSuccess, typeChar.StepWith("?").Status)
+	assert.Equal(t, world.OutcomeRejected, typeChar.StepWith("ab").Status)
+	assert.Equal(t, world.OutcomeRejected, typeChar.StepWith(1).Status)
+	assert.Equal(t, world.OutcomeRejected, typeChar.StepWith().Status)
+	assert.Equal(t, world.OutcomeRejected, typeChar.Step().Status)
+
+	assert.Len(t, f.lines[0].characters, 2)
+	assert.Equal(t, "a", f.lines[0].characters[0].shape)
+	assert.Equal(t, "?", f.lines[0].characters[1].shape)
+	assert.Len(t, w.Feel(actorId), 7)
+}
+
+func TestActionMetadata(t *testing.T) {
+	w := newTextWorld()
+	actorId, actions, _ := w.NewActor()
+	listed, err := w.Actions(actorId)
+	assert.NoError(t, err)
+	assert.Equal(t, actions, listed)
+
+	_, err = w.Actions(0)
+	assert.ErrorIs(t, err, world.ErrActorNotFound)
+
+	ids, names := map[string]bool{}, map[string]bool{}
+	categories := map[string]int{}
+	for _, action := range actions {
+		assert.NotEmpty(t, action.Id)
+		assert.NotEmpty(t, action.Description)
+		assert.Equal(t, w.Name(), action.World)
+		assert.NotContains(t, ids, action.Id)
+		assert.NotContains(t, names, action.Name)
+		ids[action.Id] = true
+		names[action.Name] = true
+		categories[action.Category]++
+	}
+
+	assert.Equal(t, map[string]int{
+		actionCategoryChangeItem: len(changeItemCmds),
+		actionCategoryPressKey:   len(pressKeyCmds),
+		actionCategorySpecialKey: len(specialKeyCmds),
+		actionCategoryTypeChar:   1,
+	}, categories)
+	assert.Contains(t, ids, "text.pressKey.a")
+	assert.Contains(t, ids, "text.specialKey.backspace")
+	assert.Contains(t, ids, "text.changeItem.itemUp")
+	assert.Contains(t, ids, "text.typeChar")
+
+	actions[0].Step()
+	assert.NotEmpty(t, w.Feel(actorId))
+	listed, err = w.Actions(actorId)
+	assert.NoError(t, err)
+	assert.Equal(t, actions, listed)
+
+	assert.NoError(t, w.RemoveActor(actorId))
+	_, err = w.Actions(actorId)
+	assert.ErrorIs(t, err, world.ErrActorNotFound)
+}
+
+func TestActionDiagnose(t *testing.T) {
+	w := newTextWorld()
+	ghost := w.pressKeyWrap(0, pressKeyCmd0)
+	assert.ErrorIs(t, ghost.Why(), world.ErrActorNotFound)
+	assert.ErrorIs(t, w.changeItemWrap(0, changeItemCmdUp).Why(), world.ErrActorNotFound)
+
+	actorId, _, _ := w.NewActor()
+	root := w.rootDirectory
+	c0 := w.pressKeyWrap(actorId, pressKeyCmd0)
+	ciU := w.changeItemWrap(actorId, changeItemCmdUp)
+	ciD := w.changeItemWrap(actorId, changeItemCmdDown)
+	ciE := w.changeItemWrap(actorId, changeItemCmdEnter)
+	assert.ErrorIs(t, c0.Why(), ErrNotInFile)
+	assert.ErrorIs(t, ciU.Why(), ErrItemCursorInvalid)
+
+	f1, _ := root.newFile("f1"), root.newFile("f2")
+	assert.ErrorIs(t, ciU.Why(), ErrItemCursorAtFirst)
+	assert.NoError(t, ciD.Why())
+	ciD.Step()
+	assert.ErrorIs(t, ciD.Why(), ErrItemCursorAtLast)
+
+	w.actors[actorId].currItemId = -1
+	assert.ErrorIs(t, c0.Why(), ErrItemNotFound)
+	assert.ErrorIs(t, ciE.Why(), ErrItemNotFound)
+
+	w.actors[actorId].currItemId = f1.id()
+	w.actors[actorId].cursorItem = 0
+	assert.NoError(t, ciE.Why())
+	assert.ErrorIs(t, ciU.Why(), ErrFileItemCmd)
+	w.actors[actorId].cursorItem = 1
+	assert.ErrorIs(t, ciE.Why(), ErrFileItemCursor)
+
+	ciL := w.specialKeyWrap(actorId, pressKeyCmdLeft)
+	ciR := w.specialKeyWrap(actorId, pressKeyCmdRight)
+	ciB := w.specialKeyWrap(actorId, pressKeyCmdBackspace)
+	ciUp := w.specialKeyWrap(actorId, pressKeyCmdUp)
+	ciDn := w.specialKeyWrap(actorId, pressKeyCmdDown)
+	assert.ErrorIs(t, ciL.Why(), ErrCursorAtLineStart)
+	assert.ErrorIs(t, ciB.Why(), ErrCursorAtLineStart)
+	assert.ErrorIs(t, ciR.Why(), ErrCursorAtLineEnd)
+	assert.ErrorIs(t, ciUp.Why(), ErrCursorAtFirstLine)
+	assert.ErrorIs(t, ciDn.Why(), ErrCursorAtLastLine)
+
+	w.actors[actorId].cursorLine = 1
+	assert.ErrorIs(t, c0.Why(), ErrLineCursorInvalid)
+	w.actors[actorId].cursorLine = 0
+	w.actors[actorId].cursorChar = 1
+	assert.ErrorIs(t, c0.Why(), ErrCharCursorInvalid)
+
+	// rejections carry the same reason as the diagnosis
+	assert.Equal(t, ErrCharCursorInvalid.Error(), c0.Step().Reason)
+}
@@ -0,0 +1,177 @@
+package text
+
+import (
+	world "github.com/sapphire-ai-dev/sapphire-world"
+)
+
+// commands accepted by textWorld.Cmd, the first argument selects the command
+const (
+	CmdSetBudget         = iota // args: actions per actor per tick int, 0 for unlimited
+	CmdSetDuration              // args: action id or category string, ticks int
+	CmdSetCooldown              // args: action id or category string, ticks int
+	CmdSetSimultaneous          // args: enabled bool, actions are queued and resolved together at Tick while enabled
+	CmdSetConflictPolicy        // args: world.ConflictPolicy deciding between actors editing the same line
+	CmdNewDirectory             // args: slash separated path string, missing parents are created as well
+	CmdNewFile                  // args: slash separated path string, optional content string replacing the content of an existing file
+)
+
+func (w *textWorld) Cmd(args ...any) error {
+	if len(args) == 0 {
+		return nil
+	}
+
+	cmd, cmdOk := args[0].(int)
+	if !cmdOk {
+		return world.ErrInvalidArgs
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	switch cmd {
+	case CmdSetBudget:
+		if len(args) != 2 {
+			return world.ErrInvalidArgs
+		}
+
+		budget, budgetOk := args[1].(int)
+		if !budgetOk || budget < 0 {
+			return world.ErrInvalidArgs
+		}
+
+		w.scheduler.SetBudget(budget)
+		return nil
+	case CmdSetDuration, CmdSetCooldown:
+		if len(args) != 3 {
+			return world.ErrInvalidArgs
+		}
+
+		key, keyOk := args[1].(string)
+		ticks, ticksOk := args[2].(int)
+		if !keyOk || !ticksOk || ticks < 0 {
+			return world.ErrInvalidArgs
+		}
+
+		if cmd == CmdSetDuration {
+			w.durations[key] = ticks
+		} else {
+			w.cooldowns[key] = ticks
+		}
+
+		for _, actorId := range w.actorIds() {
+			for _, action := range w.actions[actorId] {
+				w.applyTiming(action)
+			}
+		}
+
+		return nil
+	case CmdSetSimultaneous:
+		if len(args) != 2 {
+			return world.ErrInvalidArgs
+		}
+
+		enabled, enabledOk := args[1].(bool)
+		if !enabledOk {
+			return world.ErrInvalidArgs
+		}
+
+		w.simultaneous = enabled
+		return nil
+	case CmdSetConflictPolicy:
+		if len(args) != 2 {
+			return world.ErrInvalidArgs
+		}
+
+		policy, policyOk := args[1].(world.ConflictPolicy)
+		if !policyOk || policy == nil {
+			return world.ErrInvalidArgs
+		}
+
+		w.intents.SetPolicy(policy)
+		return nil
+	case CmdNewDirectory:
+		if len(args) != 2 {
+			return world.ErrInvalidArgs
+		}
+
+		path, pathOk := args[1].(string)
+		if !pathOk {
+			return world.ErrInvalidArgs
+		}
+
+		_, err := w.mkdir(path)
+		return err
+	case CmdNewFile:
+		if len(args) != 2 && len(args) != 3 {
+			return world.ErrInvalidArgs
+		}
+
+		path, pathOk := args[1].(string)
+		content, contentOk := "", true
+		if len(args) == 3 {
+			content, contentOk = args[2].(string)
+		}
+
+		if !pathOk || !contentOk {
+			return world.ErrInvalidArgs
+		}
+
+		_, err := w.writeFile(path, content)
+		return err
+	}
+
+	return world.ErrInvalidArgs
+}
+
+// applyTiming sets the duration and cooldown of the action, timings declared for its id take precedence over its category
+func (w *textWorld) applyTiming(action *world.ActionInterface) {
+	action.Duration = lookupTiming(w.durations, action)
+	action.Cooldown = lookupTiming(w.cooldowns, action)
+}
+
+func lookupTiming(timings map[string]int, action *world.ActionInterface) int {
+	if ticks, seen := timings[action.Id]; seen {
+		return ticks
+	}
+
+	return timings[action.Category]
+}
+
+// schedule guards the action with the world's scheduler
+func (w *textWorld) schedule(actorId int, action *world.ActionInterface) *world.ActionInterface {
+	result := w.scheduler.Wrap(actorId, action, func(outcome *world.Outcome) {
+		w.report(actorId, action.Name, outcome)
+	})
+
+	w.applyTiming(result)
+	return w.guard(result)
+}
+
+// guard makes the action hold the world's lock, the scheduler and intent queue are only touched under it
+func (w *textWorld) guard(action *world.ActionInterface) *world.ActionInterface {
+	ready, diagnose, step, stepWith := action.Ready, action.Diagnose, action.Step, action.StepWith
+	action.Ready = func() bool {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		return ready()
+	}
+	action.Diagnose = func() error {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		return diagnose()
+	}
+	action.Step = func() *world.Outcome {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		return step()
+	}
+	if stepWith != nil {
+		action.StepWith = func(args ...any) *world.Outcome {
+			w.mu.Lock()
+			defer w.mu.Unlock()
+			return stepWith(args...)
+		}
+	}
+
+	return action
+}
@@ -0,0 +1,294 @@
+package text
+
+import (
+	"testing"
+
+	world "github.com/sapphire-ai-dev/sapphire-world"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCmdErrorHandling(t *testing.T) {
+	w := newTextWorld()
+	assert.NoError(t, w.Cmd())
+	assert.ErrorIs(t, w.Cmd("1"), world.ErrInvalidArgs)
+	assert.ErrorIs(t, w.Cmd(-1), world.ErrInvalidArgs)
+	assert.ErrorIs(t, w.Cmd(CmdSetBudget), world.ErrInvalidArgs)
+	assert.ErrorIs(t, w.Cmd(CmdSetBudget, -1), world.ErrInvalidArgs)
+	assert.ErrorIs(t, w.Cmd(CmdSetDuration, actionCategoryPressKey), world.ErrInvalidArgs)
+	assert.ErrorIs(t, w.Cmd(CmdSetCooldown, 1, 1), world.ErrInvalidArgs)
+	assert.ErrorIs(t, w.Cmd(CmdSetCooldown, actionCategoryPressKey, -1), world.ErrInvalidArgs)
+}
+
+func findAction(actions []*world.ActionInterface, id string) *world.ActionInterface {
+	for _, action := range actions {
+		if action.Id == id {
+			return action
+		}
+	}
+
+	return nil
+}
+
+func TestCmdBudget(t *testing.T) {
+	w := newTextWorld()
+	actorId, actions, _ := w.NewActor()
+	f := w.rootDirectory.newFile("fName")
+	w.actors[actorId].currItemId = f.id()
+	keyA := findAction(actions, "text.pressKey.a")
+
+	assert.NoError(t, w.Cmd(CmdSetBudget, 1))
+	assert.Equal(t, world.OutcomeSuccess, keyA.Step().Status)
+	assert.ErrorIs(t, keyA.Why(), world.ErrBudgetExhausted)
+	assert.Equal(t, world.OutcomeRejected, keyA.Step().Status)
+
+	w.Tick()
+	assert.Equal(t, world.OutcomeSuccess, keyA.Step().Status)
+	assert.Len(t, f.lines[0].characters, 2)
+	tchs := w.Feel(actorId)
+	assert.Len(t, tchs, 4)
+	assert.True(t, world.IsClockTouch(tchs[3]))
+}
+
+func TestCmdDuration(t *testing.T) {
+	w := newTextWorld()
+	actorId, actions, _ := w.NewActor()
+	f := w.rootDirectory.newFile("fName")
+	w.actors[actorId].currItemId = f.id()
+	keyA := findAction(actions, "text.pressKey.a")
+	keyB := findAction(actions, "text.pressKey.b")
+
+	assert.NoError(t, w.Cmd(CmdSetDuration, actionCategoryPressKey, 2))
+	assert.NoError(t, w.Cmd(CmdSetDuration, "text.pressKey.b", 0))
+	assert.Equal(t, 2, keyA.Duration)
+	assert.Zero(t, keyB.Duration)
+
+	assert.Equal(t, world.OutcomePending, keyA.Step().Status)
+	assert.ErrorIs(t, keyB.Why(), world.ErrActorBusy)
+	w.Tick()
+	assert.Empty(t, f.lines[0].characters)
+	w.Tick()
+	assert.Len(t, f.lines[0].characters, 1)
+
+	tchs := w.Feel(actorId)
+	assert.Len(t, tchs, 3)
+	assert.Contains(t, tchs[0].Info.Labels, world.OutcomePending.Label())
+	assert.Contains(t, tchs[1].Info.Labels, world.OutcomeSuccess.Label())
+	assert.Equal(t, 2, tchs[1].Time)
+	assert.True(t, world.IsClockTouch(tchs[2]))
+
+	// newly created actors pick up the declared timings
+	_, actions2, _ := w.NewActor()
+	assert.Equal(t, 2, findAction(actions2, "text.pressKey.a").Duration)
+}
+
+func TestCmdCooldown(t *testing.T) {
+	w := newTextWorld()
+	actorId, actions, _ := w.NewActor()
+	f := w.rootDirectory.newFile("fName")
+	w.actors[actorId].currItemId = f.id()
+	keyA := findAction(actions, "text.pressKey.a")
+
+	assert.NoError(t, w.Cmd(CmdSetCooldown, "text.pressKey.a", 1))
+	keyA.Step()
+	assert.ErrorIs(t, keyA.Why(), world.ErrCooldown)
+	w.Tick()
+	assert.NoError(t, keyA.Why())
+}
+
+func lineString(l *line) string {
+	result := ""
+	for _, c := range l.characters {
+		result += c.shape
+	}
+
+	return result
+}
+
+func TestCmdSimultaneous(t *testing.T) {
+	w := newTextWorld()
+	actorId1, actions1, _ := w.NewActor()
+	actorId2, actions2, _ := w.NewActor()
+	f := w.rootDirectory.newFile("fName")
+	w.actors[actorId1].currItemId = f.id()
+	w.actors[actorId2].currItemId = f.id()
+
+	assert.ErrorIs(t, w.Cmd(CmdSetSimultaneous), world.ErrInvalidArgs)
+	assert.ErrorIs(t, w.Cmd(CmdSetSimultaneous, 1), world.ErrInvalidArgs)
+	assert.ErrorIs(t, w.Cmd(CmdSetConflictPolicy, nil), world.ErrInvalidArgs)
+	assert.NoError(t, w.Cmd(CmdSetSimultaneous, true))
+
+	// actor 2 acts first, the default policy still applies actor 1's edit first, then actor 2 types at its own cursor
+	// actor 2's cursor was at actor 1's edit and stays in front of it, actor 1's cursor moves with its "a"
+	assert.Equal(t, world.OutcomeQueued, findAction(actions2, "text.typeChar").StepWith("b").Status)
+	assert.Equal(t, world.OutcomeQueued, findAction(actions1, "text.pressKey.a").Step().Status)
+	assert.Empty(t, f.lines[0].characters)
+	w.Tick()
+	assert.Equal(t, "ba", lineString(f.lines[0]))
+	assert.Equal(t, 2, w.actors[actorId1].cursorChar)
+	assert.Equal(t, 1, w.actors[actorId2].cursorChar)
+
+	// with lowest actor wins, actor 2's edit of the same line is rejected
+	assert.NoError(t, w.Cmd(CmdSetConflictPolicy, world.ResolveLowestActorWins))
+	w.Feel(actorId1)
+	w.Feel(actorId2)
+	findAction(actions2, "text.pressKey.c").Step()
+	findAction(actions1, "text.pressKey.d").Step()
+	w.Tick()
+	assert.Equal(t, "bad", lineString(f.lines[0]))
+
+	tchs := w.Feel(actorId2)
+	assert.Len(t, tchs, 3)
+	assert.Contains(t, tchs[0].Info.Labels, world.OutcomeQueued.Label())
+	assert.Contains(t, tchs[1].Info.Labels, world.OutcomeRejected.Label())
+	assert.Equal(t, world.ErrConflict.Error(), tchs[1].Info.Value)
+
+	// actions that are not ready are rejected right away instead of being queued
+	w.actors[actorId1].currItemId = w.rootDirectory.id()
+	assert.Equal(t, world.OutcomeRejected, findAction(actions1, "text.pressKey.a").Step().Status)
+	assert.Zero(t, w.intents.Len())
+
+	assert.NoError(t, w.Cmd(CmdSetSimultaneous, false))
+	assert.Equal(t, world.OutcomeSuccess, findAction(actions2, "text.pressKey.e").Step().Status)
+}
+
+func TestCmdSimultaneousCooldown(t *testing.T) {
+	w := newTextWorld()
+	_, actions1, _ := w.NewActor()
+	_, actions2, _ := w.NewActor()
+	f := w.rootDirectory.newFile("fName")
+	for _, pos := range w.actors {
+		pos.currItemId = f.id()
+	}
+
+	assert.NoError(t, w.Cmd(CmdSetSimultaneous, true))
+	assert.NoError(t, w.Cmd(CmdSetConflictPolicy, world.ResolveLowestActorWins))
+	assert.NoError(t, w.Cmd(CmdSetCooldown, "pressKey", 2))
+
+	// a queued action cannot be queued again before it is resolved
+	keyA1, keyA2 := findAction(actions1, "text.pressKey.a"), findAction(actions2, "text.pressKey.a")
+	assert.Equal(t, world.OutcomeQueued, keyA2.Step().Status)
+	assert.Equal(t, world.OutcomeQueued, keyA1.Step().Status)
+	assert.ErrorIs(t, keyA2.Why(), world.ErrCooldown)
+
+	// only the applied intent starts its cooldown, actor 2's intent lost the conflict
+	w.Tick()
+	assert.Equal(t, "a", lineString(f.lines[0]))
+	assert.ErrorIs(t, keyA1.Why(), world.ErrCooldown)
+	assert.NoError(t, keyA2.Why())
+}
+
+func TestPressKeyInsertMiddle(t *testing.T) {
+	w := newTextWorld()
+	actorId, actions, _ := w.NewActor()
+	f := w.rootDirectory.newFile("fName")
+	w.actors[actorId].currItemId = f.id()
+	findAction(actions, "text.pressKey.a").Step()
+	findAction(actions, "text.pressKey.c").Step()
+	findAction(actions, "text.specialKey.left").Step()
+	findAction(actions, "text.pressKey.b").Step()
+	assert.Equal(t, "abc", lineString(f.lines[0]))
+}
+
+func TestBackspaceMiddle(t *testing.T) {
+	w := newTextWorld()
+	actorId, actions, _ := w.NewActor()
+	f := w.rootDirectory.newFile("fName")
+	w.actors[actorId].currItemId = f.id()
+	for _, id := range []string{"text.pressKey.a", "text.pressKey.b", "text.pressKey.c", "text.specialKey.left"} {
+		findAction(actions, id).Step()
+	}
+
+	findAction(actions, "text.specialKey.backspace").Step()
+	assert.Equal(t, "ac", lineString(f.lines[0]))
+	assert.Equal(t, 1, w.actors[actorId].cursorChar)
+}
+
+func TestEnterMiddle(t *testing.T) {
+	w := newTextWorld()
+	actorId, actions, _ := w.NewActor()
+	assert.NoError(t, w.Cmd(CmdNewFile, "fName", "x\nabcd\ny"))
+	f, _ := w.lookupFile("fName")
+	w.actors[actorId].currItemId = f.id()
+	for _, id := range []string{"text.specialKey.down", "text.specialKey.right", "text.specialKey.right"} {
+		findAction(actions, id).Step()
+	}
+
+	findAction(actions, "text.specialKey.enter").Step()
+	var lines []string
+	for _, l := range f.lines {
+		lines = append(lines, lineString(l))
+	}
+
+	assert.Equal(t, []string{"x", "ab", "cd", "y"}, lines)
+	assert.Equal(t, 2, w.actors[actorId].cursorLine)
+	assert.Zero(t, w.actors[actorId].cursorChar)
+}
+
+func TestEditShiftsOtherCursors(t *testing.T) {
+	w := newTextWorld()
+	assert.NoError(t, w.Cmd(CmdNewFile, "fName", "abcd\ny"))
+	f, _ := w.lookupFile("fName")
+	editor, actions, _ := w.NewActor()
+	before, _, _ := w.NewActor()
+	after, _, _ := w.NewActor()
+	below, _, _ := w.NewActor()
+	for id, cursor := range map[int][2]int{editor: {0, 2}, before: {0, 1}, after: {0, 3}, below: {1, 1}} {
+		w.actors[id].currItemId, w.actors[id].cursorLine, w.actors[id].cursorChar = f.id(), cursor[0], cursor[1]
+	}
+
+	cursor := func(id int) [2]int {
+		return [2]int{w.actors[id].cursorLine, w.actors[id].cursorChar}
+	}
+
+	findAction(actions, "text.pressKey.x").Step()
+	assert.Equal(t, "abxcd", lineString(f.lines[0]))
+	assert.Equal(t, [2]int{0, 1}, cursor(before))
+	assert.Equal(t, [2]int{0, 4}, cursor(after))
+
+	findAction(actions, "text.specialKey.backspace").Step()
+	assert.Equal(t, [2]int{0, 1}, cursor(before))
+	assert.Equal(t, [2]int{0, 3}, cursor(after))
+
+	// the other cursors still point at "b" and "d"
+	findAction(actions, "text.specialKey.enter").Step()
+	assert.Equal(t, "ab", lineString(f.lines[0]))
+	assert.Equal(t, [2]int{0, 1}, cursor(before))
+	assert.Equal(t, [2]int{1, 1}, cursor(after))
+	assert.Equal(t, [2]int{2, 1}, cursor(below))
+}
+
+func TestCmdNewItems(t *testing.T) {
+	w := newTextWorld()
+	assert.NoError(t, w.Cmd(CmdNewDirectory, "src/pkg"))
+	assert.NoError(t, w.Cmd(CmdNewDirectory, "/src/pkg/"))
+	src, err := w.resolve("src")
+	assert.NoError(t, err)
+	assert.Len(t, src.(*directory).content, 1)
+
+	assert.NoError(t, w.Cmd(CmdNewFile, "src/pkg/main", "ab\nc"))
+	found, err := w.resolve("src/pkg/main")
+	assert.NoError(t, err)
+	f := found.(*file)
+	assert.Equal(t, "ab\nc", f.content())
+	assert.Len(t, f.lines, 2)
+
+	// writing an existing file replaces its content and moves the actors inside to its start
+	actorId, _, _ := w.NewActor()
+	w.actors[actorId].currItemId = f.id()
+	w.actors[actorId].cursorLine, w.actors[actorId].cursorChar = 1, 1
+	assert.NoError(t, w.Cmd(CmdNewFile, "src/pkg/main"))
+	assert.Equal(t, "", f.content())
+	assert.Zero(t, w.actors[actorId].cursorLine)
+	assert.Zero(t, w.actors[actorId].cursorChar)
+
+	assert.ErrorIs(t, w.Cmd(CmdNewDirectory, "src/pkg/main"), ErrItemExists)
+	assert.ErrorIs(t, w.Cmd(CmdNewFile, "src"), ErrItemExists)
+	assert.ErrorIs(t, w.Cmd(CmdNewFile, "src/pkg/main/inner"), ErrNotDirectory)
+	assert.ErrorIs(t, w.Cmd(CmdNewFile, "/"), ErrPathEmpty)
+	assert.ErrorIs(t, w.Cmd(CmdNewDirectory, ""), ErrPathEmpty)
+	assert.ErrorIs(t, w.Cmd(CmdNewFile, "other", "\t"), world.ErrInvalidArgs)
+	assert.ErrorIs(t, w.Cmd(CmdNewFile, 1), world.ErrInvalidArgs)
+	assert.ErrorIs(t, w.Cmd(CmdNewDirectory), world.ErrInvalidArgs)
+	_, err = w.resolve("missing/main")
+	assert.ErrorIs(t, err, ErrPathNotFound)
+}
@@ -0,0 +1,63 @@
+package text
+
+import (
+	"sort"
+
+	world "github.com/sapphire-ai-dev/sapphire-world"
+)
+
+// Digest hashes the directory tree, file lines and character shapes, then the cursors and pending touches of every actor in id order
+// followed by everything deciding the outcome of future steps: the scheduler, action timings, the conflict mode and queued intents
+func (w *textWorld) Digest() (world.Digest, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	d := world.NewDigester().String(w.Name()).Int(w.clock, w.s.LastUnitId())
+	w.rootDirectory.digest(d)
+
+	actorIds := w.actorIds()
+	d.Int(len(actorIds))
+	for _, id := range actorIds {
+		pos := w.actors[id]
+		d.Int(id, pos.currItemId, pos.cursorItem, pos.cursorLine, pos.cursorChar, w.felt[id])
+		d.Bool(w.scheduler.Busy(id)).Int(len(w.touches[id]))
+		for _, touch := range w.touches[id] {
+			d.Touch(touch)
+		}
+	}
+
+	d.Scheduler(w.scheduler)
+	digestTimings(d, w.durations)
+	digestTimings(d, w.cooldowns)
+	return d.Bool(w.simultaneous).Intents(w.intents).Sum(), nil
+}
+
+// digestTimings writes action timings in key order
+func digestTimings(d *world.Digester, timings map[string]int) {
+	var keys []string
+	for key := range timings {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	d.Int(len(keys))
+	for _, key := range keys {
+		d.String(key).Int(timings[key])
+	}
+}
+
+func (d *directory) digest(digester *world.Digester) {
+	digester.String(itemTypeDirectory).Int(d.i).String(d.n).Int(len(d.content))
+	for _, elem := range d.content {
+		elem.digest(digester)
+	}
+}
+
+func (f *file) digest(digester *world.Digester) {
+	digester.String(itemTypeFile).Int(f.i).String(f.n).Int(len(f.lines))
+	for _, l := range f.lines {
+		digester.Int(l.id, len(l.characters))
+		for _, c := range l.characters {
+			digester.Int(c.id).String(c.shape)
+		}
+	}
+}
@@ -12,24 +12,28 @@ const (
 	contentTypeRoot   = "[contentType]"
 	contentTypeLine   = "[line]"
 	lineDirection     = "[lineDirection]"
-	charDirection     = "[lineDirection]"
+	charDirection     = "[charDirection]"
 )
 
 type item interface {
 	id() int
 	parent() item
 	name() string
+	contentVersion() int
 	itemImg(itemDelta int) *world.Image
 	dirImgs(actorPos int) []*world.Image
 	fileImgs(cursorLine, cursorChar int) []*world.Image
+	clone(w *textWorld, parent item, items map[int]item) item
+	digest(d *world.Digester)
 }
 
 type abstractItem struct {
-	w    *textWorld
-	self item
-	i    int
-	p    item
-	n    string
+	w       *textWorld
+	self    item
+	i       int
+	p       item
+	n       string
+	version int // bumped whenever the content of the item changes
 }
 
 func (a *abstractItem) id() int {
@@ -44,6 +48,14 @@ func (a *abstractItem) name() string {
 	return a.n
 }
 
+func (a *abstractItem) modified() {
+	a.version++
+}
+
+func (a *abstractItem) contentVersion() int {
+	return a.version
+}
+
 func itemImg(id int, name string, itemType string, itemDelta int) *world.Image {
 	itemDir := world.TernaryZro
 	if itemDelta > 0 {
@@ -74,7 +86,7 @@ func (w *textWorld) newAbstractItem(self, parent item, name string, out **abstra
 	*out = &abstractItem{
 		w:    w,
 		self: self,
-		i:    world.NewUnitId(),
+		i:    w.s.NewUnitId(),
 		p:    parent,
 		n:    name,
 	}
@@ -118,6 +130,7 @@ func (d *directory) newDirectory(name string) *directory {
 
 	d.w.newAbstractItem(result, d, name, &result.abstractItem)
 	d.content = append(d.content, result)
+	d.modified()
 	return result
 }
 
@@ -152,10 +165,10 @@ func (f *file) fileImgs(cursorLine, cursorChar int) []*world.Image {
 
 func (d *directory) newFile(name string) *file {
 	result := &file{}
-	result.lines = []*line{result.newLine()}
-
 	d.w.newAbstractItem(result, d, name, &result.abstractItem)
+	result.lines = []*line{result.newLine()}
 	d.content = append(d.content, result)
+	d.modified()
 	return result
 }
 
@@ -200,7 +213,7 @@ func (l *line) img(cursorLineDelta, cursorChar int) []*world.Image {
 
 func (f *file) newLine() *line {
 	result := &line{
-		id:         world.NewUnitId(),
+		id:         f.w.s.NewUnitId(),
 		parent:     f,
 		characters: []*character{},
 	}
@@ -210,6 +223,7 @@ func (f *file) newLine() *line {
 
 func (f *file) appendLine(line *line) {
 	f.lines = append(f.lines, line)
+	f.modified()
 }
 
 type character struct {
@@ -246,7 +260,7 @@ func (c *character) img(cursorCharDelta int) *world.Image {
 
 func (l *line) newCharacter(shape string) *character {
     result := &character{
-        id:     world.NewUnitId(),
+        id:     l.parent.w.s.NewUnitId(),
         parent: l,
         shape:  shape,
     }
@@ -42,7 +42,7 @@ func TestFileConstructor(t *testing.T) {
 func TestDirectoryFileImage(t *testing.T) {
 	w := newTextWorld()
 	root := w.rootDirectory
-	actorId, _ := w.NewActor()
+	actorId, _, _ := w.NewActor()
 	actor := w.actors[actorId]
 	assert.Empty(t, root.fileImgs(actor.cursorLine, actor.cursorChar))
 }
@@ -50,7 +50,7 @@ func TestDirectoryFileImage(t *testing.T) {
 func TestDirectoryImageFormat(t *testing.T) {
 	w := newTextWorld()
 	root := w.rootDirectory
-	actorId, _ := w.NewActor()
+	actorId, _, _ := w.NewActor()
 
 	dName := "abc"
 	d := root.newDirectory(dName)
@@ -71,7 +71,7 @@ func TestDirectoryImageFormat(t *testing.T) {
 func TestFileImageFormat(t *testing.T) {
 	w := newTextWorld()
 	root := w.rootDirectory
-	actorId, _ := w.NewActor()
+	actorId, _, _ := w.NewActor()
 
 	fName := "abc"
 	f := root.newFile(fName)
@@ -92,7 +92,7 @@ func TestFileImageFormat(t *testing.T) {
 func TestDirectoryImage(t *testing.T) {
 	w := newTextWorld()
 	root := w.rootDirectory
-	actorId, _ := w.NewActor()
+	actorId, _, _ := w.NewActor()
 
 	d1Name, d2Name, d31Name, d32Name := "d1", "d2", "d31", "d32"
 	d1 := root.newDirectory(d1Name)
@@ -140,7 +140,7 @@ func TestDirectoryImage(t *testing.T) {
 func TestFileImage(t *testing.T) {
 	w := newTextWorld()
 	root := w.rootDirectory
-	actorId, _ := w.NewActor()
+	actorId, _, _ := w.NewActor()
 
 	dName, f1Name, f2Name := "d", "f1", "f2"
 	d := root.newDirectory(dName)
@@ -195,7 +195,7 @@ func TestLineConstructor(t *testing.T) {
 func TestLineImageFormat(t *testing.T) {
 	w := newTextWorld()
 	root := w.rootDirectory
-	actorId, _ := w.NewActor()
+	actorId, _, _ := w.NewActor()
 	f := root.newFile("")
 	l := f.lines[0]
 	w.actors[actorId].currItemId = f.id()
@@ -220,7 +220,7 @@ func TestLineImageFormat(t *testing.T) {
 func TestLineImage(t *testing.T) {
 	w := newTextWorld()
 	root := w.rootDirectory
-	actorId, _ := w.NewActor()
+	actorId, _, _ := w.NewActor()
 	f := root.newFile("")
 	l1 := f.lines[0]
 	l2 := f.newLine()
@@ -278,7 +278,7 @@ func TestCharacterConstructor(t *testing.T) {
 func TestCharImageFormat(t *testing.T) {
 	w := newTextWorld()
 	root := w.rootDirectory
-	actorId, _ := w.NewActor()
+	actorId, _, _ := w.NewActor()
 	f := root.newFile("")
 	l := f.lines[0]
 	shape := pressKeyCmds[pressKeyCmd0]
@@ -307,7 +307,7 @@ func TestCharImageFormat(t *testing.T) {
 func TestCharImage(t *testing.T) {
 	w := newTextWorld()
 	root := w.rootDirectory
-	actorId, _ := w.NewActor()
+	actorId, _, _ := w.NewActor()
 	f := root.newFile("")
 	l := f.lines[0]
 	shape1, shape2, shape3 := pressKeyCmds[pressKeyCmd1], pressKeyCmds[pressKeyCmd2], pressKeyCmds[pressKeyCmd3]
@@ -0,0 +1,170 @@
+package text
+
+import (
+	"errors"
+	"strings"
+
+	world "github.com/sapphire-ai-dev/sapphire-world"
+)
+
+var (
+	ErrPathEmpty    = errors.New("path names no item")
+	ErrPathNotFound = errors.New("no item at path")
+	ErrNotDirectory = errors.New("path leads through a file")
+	ErrItemExists   = errors.New("an item of another type exists at path")
+)
+
+// splitPath splits a slash separated path relative to the root directory, empty segments are ignored
+func splitPath(path string) []string {
+	var result []string
+	for _, name := range strings.Split(path, "/") {
+		if name != "" {
+			result = append(result, name)
+		}
+	}
+
+	return result
+}
+
+// child returns the first item of the directory with the given name
+func (d *directory) child(name string) item {
+	for _, elem := range d.content {
+		if elem.name() == name {
+			return elem
+		}
+	}
+
+	return nil
+}
+
+// parentDirectory walks to the directory holding the last item of the path, creating missing directories if create is set
+func (w *textWorld) parentDirectory(names []string, create bool) (*directory, error) {
+	curr := w.rootDirectory
+	for _, name := range names[:len(names)-1] {
+		next := curr.child(name)
+		if next == nil {
+			if !create {
+				return nil, ErrPathNotFound
+			}
+
+			next = curr.newDirectory(name)
+		}
+
+		dir, isDir := next.(*directory)
+		if !isDir {
+			return nil, ErrNotDirectory
+		}
+
+		curr = dir
+	}
+
+	return curr, nil
+}
+
+func (w *textWorld) resolve(path string) (item, error) {
+	names := splitPath(path)
+	if len(names) == 0 {
+		return w.rootDirectory, nil
+	}
+
+	parent, err := w.parentDirectory(names, false)
+	if err != nil {
+		return nil, err
+	}
+
+	result := parent.child(names[len(names)-1])
+	if result == nil {
+		return nil, ErrPathNotFound
+	}
+
+	return result, nil
+}
+
+// mkdir creates the directory at path together with its missing parents, an existing directory is kept
+func (w *textWorld) mkdir(path string) (*directory, error) {
+	names := splitPath(path)
+	if len(names) == 0 {
+		return nil, ErrPathEmpty
+	}
+
+	parent, err := w.parentDirectory(names, true)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := parent.child(names[len(names)-1])
+	if existing == nil {
+		return parent.newDirectory(names[len(names)-1]), nil
+	}
+
+	if dir, isDir := existing.(*directory); isDir {
+		return dir, nil
+	}
+
+	return nil, ErrItemExists
+}
+
+// writeFile creates the file at path together with its missing parents, replacing the content of an existing file
+// actors inside a replaced file are moved to its start
+// content may only hold characters the keys can type, lines are separated by \n
+func (w *textWorld) writeFile(path, content string) (*file, error) {
+	for _, r := range content {
+		if _, typeable := pressKeyCmdOf(string(r)); !typeable && r != '\n' {
+			return nil, world.ErrInvalidArgs
+		}
+	}
+
+	names := splitPath(path)
+	if len(names) == 0 {
+		return nil, ErrPathEmpty
+	}
+
+	parent, err := w.parentDirectory(names, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var result *file
+	switch existing := parent.child(names[len(names)-1]).(type) {
+	case nil:
+		result = parent.newFile(names[len(names)-1])
+	case *file:
+		result = existing
+	default:
+		return nil, ErrItemExists
+	}
+
+	result.lines = nil
+	for _, text := range strings.Split(content, "\n") {
+		l := result.newLine()
+		for _, r := range text {
+			l.characters = append(l.characters, l.newCharacter(string(r)))
+		}
+
+		result.lines = append(result.lines, l)
+	}
+
+	result.modified()
+	for _, pos := range w.actors {
+		if pos.currItemId == result.id() {
+			pos.cursorLine, pos.cursorChar = 0, 0
+		}
+	}
+
+	return result, nil
+}
+
+// content joins the lines of the file with \n
+func (f *file) content() string {
+	lines := make([]string, 0, len(f.lines))
+	for _, l := range f.lines {
+		shapes := make([]string, 0, len(l.characters))
+		for _, c := range l.characters {
+			shapes = append(shapes, c.shape)
+		}
+
+		lines = append(lines, strings.Join(shapes, ""))
+	}
+
+	return strings.Join(lines, "\n")
+}
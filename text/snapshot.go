@@ -0,0 +1,158 @@
+package text
+
+import world "github.com/sapphire-ai-dev/sapphire-world"
+
+// textState is the world specific part of a text world snapshot, its item tree is a private copy
+type textState struct {
+	owner        *textWorld
+	root         *directory
+	actors       map[int]actorPos
+	touches      map[int][]*world.Touch
+	actions      map[int][]*world.ActionInterface
+	scheduler    *world.SchedulerState
+	durations    map[string]int
+	cooldowns    map[string]int
+	policy       world.ConflictPolicy
+	simultaneous bool
+	felt         map[int]int
+}
+
+// Snapshot saves the state of the world, intents queued while simultaneous actions are enabled are resolved by the next Tick and not saved
+func (w *textWorld) Snapshot() (*world.Snapshot, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	root, _ := cloneTree(w, w.rootDirectory)
+	state := &textState{
+		owner:        w,
+		root:         root,
+		actors:       map[int]actorPos{},
+		touches:      copyTouches(w.touches),
+		actions:      map[int][]*world.ActionInterface{},
+		scheduler:    w.scheduler.Save(),
+		durations:    copyTimings(w.durations),
+		cooldowns:    copyTimings(w.cooldowns),
+		policy:       w.intents.Policy(),
+		simultaneous: w.simultaneous,
+		felt:         map[int]int{},
+	}
+
+	for id, pos := range w.actors {
+		state.actors[id] = *pos
+		state.actions[id] = w.actions[id]
+		state.felt[id] = w.felt[id]
+	}
+
+	return &world.Snapshot{World: w.Name(), Clock: w.clock, UnitId: w.s.LastUnitId(), State: state}, nil
+}
+
+// Restore brings the world back to the snapshot, the next LookDelta of every actor reports its full observation
+func (w *textWorld) Restore(snapshot *world.Snapshot) error {
+	if snapshot == nil {
+		return world.ErrInvalidArgs
+	}
+
+	state, ok := snapshot.State.(*textState)
+	if !ok || state.owner != w {
+		return world.ErrSnapshotMismatch
+	}
+
+	w.mu.Lock()
+	before, after := map[int]bool{}, map[int]bool{}
+	for id := range w.actors {
+		before[id] = true
+	}
+
+	w.rootDirectory, w.items = cloneTree(w, state.root)
+	w.actors = map[int]*actorPos{}
+	w.actions = map[int][]*world.ActionInterface{}
+	w.felt = map[int]int{}
+	for id, pos := range state.actors {
+		pos := pos
+		after[id] = true
+		w.actors[id] = &pos
+		w.actions[id] = state.actions[id]
+		w.felt[id] = state.felt[id]
+	}
+
+	for id := range before {
+		if !after[id] {
+			w.cycles.RemoveActor(id)
+		}
+	}
+
+	w.touches = copyTouches(state.touches)
+	w.scheduler.Load(state.scheduler)
+	w.durations = copyTimings(state.durations)
+	w.cooldowns = copyTimings(state.cooldowns)
+	w.intents = world.NewIntentQueue(state.policy)
+	w.simultaneous = state.simultaneous
+	w.deltas = world.NewDeltaTracker()
+	w.looked = map[int]lookState{}
+	w.clock = snapshot.Clock
+	w.s.SetLastUnitId(snapshot.UnitId)
+	w.mu.Unlock()
+
+	w.lifecycle.EmitRestored(before, after)
+	return nil
+}
+
+func copyTouches(touches map[int][]*world.Touch) map[int][]*world.Touch {
+	result := map[int][]*world.Touch{}
+	for id, actorTouches := range touches {
+		for _, touch := range actorTouches {
+			copied := *touch
+			result[id] = append(result[id], &copied)
+		}
+	}
+
+	return result
+}
+
+func copyTimings(timings map[string]int) map[string]int {
+	result := map[string]int{}
+	for key, ticks := range timings {
+		result[key] = ticks
+	}
+
+	return result
+}
+
+// cloneTree deep copies the item tree under root for w, returning the copy and its items by id
+func cloneTree(w *textWorld, root *directory) (*directory, map[int]item) {
+	items := map[int]item{}
+	return root.clone(w, nil, items).(*directory), items
+}
+
+func (a *abstractItem) cloneAs(w *textWorld, self, parent item, items map[int]item) *abstractItem {
+	items[a.i] = self
+	return &abstractItem{w: w, self: self, i: a.i, p: parent, n: a.n, version: a.version}
+}
+
+func (d *directory) clone(w *textWorld, parent item, items map[int]item) item {
+	result := &directory{content: []item{}}
+	result.abstractItem = d.cloneAs(w, result, parent, items)
+	for _, elem := range d.content {
+		result.content = append(result.content, elem.clone(w, result, items))
+	}
+
+	return result
+}
+
+func (f *file) clone(w *textWorld, parent item, items map[int]item) item {
+	result := &file{}
+	result.abstractItem = f.cloneAs(w, result, parent, items)
+	for _, l := range f.lines {
+		result.lines = append(result.lines, l.clone(result))
+	}
+
+	return result
+}
+
+func (l *line) clone(parent *file) *line {
+	result := &line{id: l.id, parent: parent, characters: make([]*character, 0, len(l.characters))}
+	for _, c := range l.characters {
+		result.characters = append(result.characters, &character{id: c.id, parent: result, shape: c.shape})
+	}
+
+	return result
+}
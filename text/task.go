@@ -0,0 +1,129 @@
+package text
+
+import (
+	"fmt"
+	"strings"
+
+	world "github.com/sapphire-ai-dev/sapphire-world"
+)
+
+// find unwraps w until it reaches a text world, so tasks work on text worlds wrapped by Stream, Debug or a Recorder
+func find(w world.SafeWorld) (*textWorld, bool) {
+	for w != nil {
+		if tw, ok := w.(*textWorld); ok {
+			return tw, true
+		}
+
+		w = world.Unwrap(w)
+	}
+
+	return nil, false
+}
+
+var succeeded = world.TaskStatus{Reward: 1, Success: true, Terminated: true}
+
+type fileContainsTask struct {
+	path string
+	text string
+}
+
+/*
+FileContains
+
+	# task of making the file at path contain text, Reset creates the file empty together with its missing parents
+	# every actor succeeds on the first tick the file contains text, earning a reward of 1
+*/
+func FileContains(path, text string) world.Task {
+	return &fileContainsTask{path: path, text: text}
+}
+
+func (t *fileContainsTask) Name() string {
+	return fmt.Sprintf("text.fileContains(%s, %q)", t.path, t.text)
+}
+
+func (t *fileContainsTask) Reset(w world.SafeWorld) error {
+	tw, ok := find(w)
+	if !ok {
+		return world.ErrUnsupported
+	}
+
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	_, err := tw.writeFile(t.path, "")
+	return err
+}
+
+func (t *fileContainsTask) Evaluate(w world.SafeWorld, _ int) world.TaskStatus {
+	tw, ok := find(w)
+	if !ok {
+		return world.TaskStatus{}
+	}
+
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if f, isFile := tw.lookupFile(t.path); isFile && strings.Contains(f.content(), t.text) {
+		return succeeded
+	}
+
+	return world.TaskStatus{}
+}
+
+type openFileTask struct {
+	path string
+}
+
+/*
+OpenFile
+
+	# task of navigating into the file at path, Reset creates the file if it does not exist yet
+	# an actor succeeds on the first tick it is inside the file, earning a reward of 1
+*/
+func OpenFile(path string) world.Task {
+	return &openFileTask{path: path}
+}
+
+func (t *openFileTask) Name() string {
+	return fmt.Sprintf("text.openFile(%s)", t.path)
+}
+
+func (t *openFileTask) Reset(w world.SafeWorld) error {
+	tw, ok := find(w)
+	if !ok {
+		return world.ErrUnsupported
+	}
+
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if _, isFile := tw.lookupFile(t.path); isFile {
+		return nil
+	}
+
+	_, err := tw.writeFile(t.path, "")
+	return err
+}
+
+func (t *openFileTask) Evaluate(w world.SafeWorld, actorId int) world.TaskStatus {
+	tw, ok := find(w)
+	if !ok {
+		return world.TaskStatus{}
+	}
+
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	f, isFile := tw.lookupFile(t.path)
+	if pos, seen := tw.actors[actorId]; seen && isFile && pos.currItemId == f.id() {
+		return succeeded
+	}
+
+	return world.TaskStatus{}
+}
+
+func (w *textWorld) lookupFile(path string) (*file, bool) {
+	found, err := w.resolve(path)
+	if err != nil {
+		return nil, false
+	}
+
+	f, isFile := found.(*file)
+	return f, isFile
+}
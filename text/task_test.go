@@ -0,0 +1,66 @@
+package text
+
+import (
+	"testing"
+
+	world "github.com/sapphire-ai-dev/sapphire-world"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileContains(t *testing.T) {
+	tw := newSessionTextWorld(world.NewSession())
+	assert.NoError(t, tw.Cmd(CmdNewFile, "notes", "ab"))
+	w, err := world.Attach(world.Stream(tw), FileContains("notes", "ba"), world.MaxTicks(10))
+	assert.NoError(t, err)
+	assert.Contains(t, w.Task().Name(), "notes")
+
+	// the file is emptied when the episode starts
+	f, _ := tw.lookupFile("notes")
+	assert.Empty(t, f.content())
+
+	actorId, actions, _ := w.NewActor()
+	findAction(actions, "text.changeItem.itemEnter").Step()
+	typed := []string{"b", "a"}
+	w.Register(actorId, func() {
+		if len(typed) > 0 {
+			findAction(actions, "text.typeChar").StepWith(typed[0])
+			typed = typed[1:]
+		}
+	})
+
+	w.Tick()
+	status, _ := w.Status(actorId)
+	assert.False(t, status.Done())
+	w.Tick()
+	status, _ = w.Status(actorId)
+	assert.Equal(t, world.TaskStatus{Reward: 1, Return: 1, Success: true, Terminated: true}, status)
+
+	// a new episode starts with an empty file again
+	w.Reset()
+	f, _ = tw.lookupFile("notes")
+	assert.Empty(t, f.content())
+}
+
+func TestOpenFile(t *testing.T) {
+	tw := newSessionTextWorld(world.NewSession())
+	assert.NoError(t, tw.Cmd(CmdNewDirectory, "docs"))
+	w, err := world.Attach(tw, OpenFile("docs/readme"))
+	assert.NoError(t, err)
+
+	actorId, actions, _ := w.NewActor()
+	otherId, _, _ := w.NewActor()
+	for _, id := range []string{"text.changeItem.itemEnter", "text.changeItem.itemDown", "text.changeItem.itemEnter"} {
+		assert.Equal(t, world.OutcomeSuccess, findAction(actions, id).Step().Status)
+	}
+
+	w.Tick()
+	status, _ := w.Status(actorId)
+	assert.True(t, status.Success)
+	status, _ = w.Status(otherId)
+	assert.False(t, status.Done())
+
+	_, err = world.Attach(world.Recover(&struct{ world.World }{}), OpenFile("docs/readme"))
+	assert.ErrorIs(t, err, world.ErrUnsupported)
+	_, err = world.Attach(tw, OpenFile("docs"))
+	assert.ErrorIs(t, err, ErrItemExists)
+}
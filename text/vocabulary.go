@@ -0,0 +1,59 @@
+package text
+
+import (
+	world "github.com/sapphire-ai-dev/sapphire-world"
+)
+
+var vocabulary = newVocabulary()
+
+func init() {
+	world.RegisterVocabulary(vocabulary)
+}
+
+// newVocabulary panics if two label families share a root, so such typos fail at startup
+func newVocabulary() *world.Vocabulary {
+	result := world.NewVocabulary("text")
+	for _, spec := range []*world.LabelSpec{
+		{
+			Root:        itemTypeRoot,
+			Members:     []string{itemTypeDirectory, itemTypeFile},
+			ValueType:   world.ValueNone,
+			Description: "kind of an item inside a directory",
+		},
+		{
+			Root:        itemDirection,
+			Members:     world.Ternary,
+			ValueType:   world.ValueInt,
+			Description: "position of an item relative to the item cursor, the value is the distance",
+		},
+		{
+			Root:        contentTypeRoot,
+			Members:     append([]string{contentTypeLine}, typeableChars()...),
+			OpenMembers: true,
+			ValueType:   world.ValueNone,
+			Description: "content of a file, either [line] or the shape of a character",
+		},
+		{
+			Root:        lineDirection,
+			Members:     world.Ternary,
+			ValueType:   world.ValueInt,
+			Description: "position of a line relative to the cursor line, the value is the distance",
+		},
+		{
+			Root:        charDirection,
+			Members:     world.Ternary,
+			ValueType:   world.ValueInt,
+			Description: "position of a character relative to the cursor, the value is the distance",
+		},
+	} {
+		if err := result.Declare(spec); err != nil {
+			panic(err)
+		}
+	}
+
+	return result
+}
+
+func (w *textWorld) Vocabulary() *world.Vocabulary {
+	return vocabulary
+}
@@ -1,16 +1,39 @@
 package text
 
 import (
-	"errors"
+	"sort"
+	"sync"
 
 	world "github.com/sapphire-ai-dev/sapphire-world"
 )
 
+// textWorld is safe for concurrent use, mu serializes every access to its state including action steps
+// cycle functions run without holding mu so that they can perform actions
 type textWorld struct {
+	mu            sync.Mutex
+	s             *world.Session
 	rootDirectory *directory
 	items         map[int]item
 	actors        map[int]*actorPos
-	cycleFuncs    map[int]func()
+	touches       map[int][]*world.Touch           // actorId -> touches not yet felt
+	actions       map[int][]*world.ActionInterface // actorId -> action interfaces
+	cycles        *world.CycleRegistry
+	lifecycle     *world.Lifecycle
+	scheduler     *world.Scheduler
+	durations     map[string]int // action id or category -> ticks
+	cooldowns     map[string]int // action id or category -> ticks
+	intents       *world.IntentQueue
+	simultaneous  bool
+	deltas        *world.DeltaTracker
+	looked        map[int]lookState // actorId -> state of the previous LookDelta
+	clock         int
+	felt          map[int]int // actorId -> clock at the previous Feel
+}
+
+// lookState captures everything an actor's observation depends on
+type lookState struct {
+	pos     actorPos
+	version int
 }
 
 type actorPos struct {
@@ -33,42 +56,133 @@ func (w *textWorld) Name() string {
 	return "text"
 }
 
+// Reset eliminates all actors, emitting ActorRemoved for each of them in id order
 func (w *textWorld) Reset() {
+	w.mu.Lock()
+	removed := w.actorIds()
 	w.items = map[int]item{}
 	w.actors = map[int]*actorPos{}
-	w.cycleFuncs = map[int]func(){}
+	w.touches = map[int][]*world.Touch{}
+	w.actions = map[int][]*world.ActionInterface{}
+	w.cycles.Clear()
+	w.scheduler.Clear()
+	w.durations = map[string]int{}
+	w.cooldowns = map[string]int{}
+	w.intents = world.NewIntentQueue(world.ResolveByActorId)
+	w.simultaneous = false
+	w.deltas = world.NewDeltaTracker()
+	w.looked = map[int]lookState{}
+	w.clock = 0
+	w.felt = map[int]int{}
 	w.rootDirectory = &directory{
 		content: []item{},
 	}
 
 	w.newAbstractItem(w.rootDirectory, nil, "", &w.rootDirectory.abstractItem)
+	w.mu.Unlock()
+
+	for _, id := range removed {
+		w.lifecycle.Emit(world.ActorRemoved, id)
+	}
 }
 
-func (w *textWorld) Tick() {
-	for _, f := range w.cycleFuncs {
-		f()
+func (w *textWorld) actorIds() []int {
+	var result []int
+	for id := range w.actors {
+		result = append(result, id)
 	}
+
+	sort.Ints(result)
+	return result
 }
 
-func (w *textWorld) NewActor(_ ...any) (int, []*world.ActionInterface) {
-	id := world.NewUnitId()
+func (w *textWorld) Tick() {
+	w.mu.Lock()
+	w.clock++
+	w.scheduler.Advance()
+	cycles := w.cycles
+	w.mu.Unlock()
+
+	cycles.Run()
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.intents.Resolve(func(intent *world.Intent, outcome *world.Outcome) {
+		w.report(intent.ActorId, intent.Name, outcome)
+	})
+	w.scheduler.Settle()
+}
+
+func (w *textWorld) NewActor(_ ...any) (int, []*world.ActionInterface, error) {
+	w.mu.Lock()
+	id := w.s.NewUnitId()
 	w.actors[id] = w.newActorPos()
-	return id, w.newActionInterfaces(id)
+	w.felt[id] = w.clock
+	for _, action := range w.newActionInterfaces(id) {
+		w.actions[id] = append(w.actions[id], w.schedule(id, action))
+	}
+
+	actions := w.actions[id]
+	w.mu.Unlock()
+
+	w.lifecycle.Emit(world.ActorSpawned, id)
+	return id, actions, nil
 }
 
-var (
-	errActorNotFound = errors.New("actor not found")
-)
+func (w *textWorld) RemoveActor(id int) error {
+	w.mu.Lock()
+	if _, seen := w.actors[id]; !seen {
+		w.mu.Unlock()
+		return world.ErrActorNotFound
+	}
+
+	delete(w.actors, id)
+	delete(w.touches, id)
+	delete(w.actions, id)
+	w.cycles.RemoveActor(id)
+	w.scheduler.RemoveActor(id)
+	w.intents.RemoveActor(id)
+	w.deltas.RemoveActor(id)
+	delete(w.looked, id)
+	delete(w.felt, id)
+	w.mu.Unlock()
 
-func (w *textWorld) Register(id int, cycle func()) {
+	w.lifecycle.Emit(world.ActorRemoved, id)
+	return nil
+}
+
+func (w *textWorld) Lifecycle() *world.Lifecycle {
+	return w.lifecycle
+}
+
+func (w *textWorld) Register(id int, cycle func(), opts ...world.CycleOption) (*world.CycleHandle, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
 	if _, seen := w.actors[id]; !seen {
-		panic(errActorNotFound)
+		return nil, world.ErrActorNotFound
+	}
+
+	return w.cycles.Register(id, cycle, opts...)
+}
+
+func (w *textWorld) Actions(id int) ([]*world.ActionInterface, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	actions, seen := w.actions[id]
+	if !seen {
+		return nil, world.ErrActorNotFound
 	}
 
-	w.cycleFuncs[id] = cycle
+	return actions, nil
 }
 
 func (w *textWorld) Look(id int) []*world.Image {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.look(id)
+}
+
+func (w *textWorld) look(id int) []*world.Image {
 	actor, actorSeen := w.actors[id]
 	if !actorSeen {
 		return []*world.Image{}
@@ -80,22 +194,74 @@ func (w *textWorld) Look(id int) []*world.Image {
 
 	result := currItem.fileImgs(actor.cursorLine, actor.cursorChar)
 	result = append(result, currItem.dirImgs(actor.cursorItem)...)
+	for _, img := range result {
+		img.Time = w.clock
+	}
+
 	return result
 }
 
-func (w *textWorld) Feel(_ int) []*world.Touch {
-	return []*world.Touch{}
+func (w *textWorld) Clock() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.clock
+}
+
+// LookDelta skips rebuilding the observation when neither the actor nor the item it is on changed since its previous LookDelta
+func (w *textWorld) LookDelta(id int) *world.ImageDelta {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	actor, actorSeen := w.actors[id]
+	if !actorSeen {
+		return w.deltas.Diff(id, nil)
+	}
+
+	state := lookState{pos: *actor}
+	if currItem, itemSeen := w.items[actor.currItemId]; itemSeen {
+		state.version = currItem.contentVersion()
+	}
+
+	if last, seen := w.looked[id]; seen && last == state {
+		return &world.ImageDelta{}
+	}
+
+	w.looked[id] = state
+	return w.deltas.Diff(id, w.look(id))
 }
 
-func (w *textWorld) Cmd(_ ...any) {}
+// Feel returns the outcomes of the actions performed since the previous Feel, followed by a clock touch if time passed
+func (w *textWorld) Feel(id int) []*world.Touch {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	result := w.touches[id]
+	if result == nil {
+		result = []*world.Touch{}
+	}
+
+	if felt, seen := w.felt[id]; seen && felt < w.clock {
+		result = append(result, world.ClockTouch(id, w.clock))
+		w.felt[id] = w.clock
+	}
+
+	delete(w.touches, id)
+	return result
+}
 
 func newTextWorld() *textWorld {
-    result := &textWorld{}
-    result.Reset()
-    return result
+	return newSessionTextWorld(world.DefaultSession())
+}
+
+func newSessionTextWorld(s *world.Session) *textWorld {
+	result := &textWorld{s: s, lifecycle: world.NewLifecycle(), cycles: world.NewCycleRegistry(), scheduler: world.NewScheduler()}
+	result.Reset()
+	return result
 }
 
 func Init() {
-    w := newTextWorld()
-    world.SetWorld(w)
+	InitSession(world.DefaultSession())
+}
+
+// InitSession installs a new text world into the given session
+func InitSession(s *world.Session) {
+	s.SetSafeWorld(newSessionTextWorld(s))
 }
@@ -1,6 +1,7 @@
 package text
 
 import (
+	"math/rand"
 	"testing"
 
 	world "github.com/sapphire-ai-dev/sapphire-world"
@@ -16,7 +17,7 @@ func TestTextWorldConstructor(t *testing.T) {
 	assert.NotNil(t, w.rootDirectory)
 	assert.Len(t, w.items, 1)
 	assertEmptyNotNil(t, w.actors)
-	assertEmptyNotNil(t, w.cycleFuncs)
+	assert.Zero(t, w.cycles.Len())
 	assert.Equal(t, w.Name(), "text")
 }
 
@@ -40,9 +41,11 @@ func TestTextWorldRegister(t *testing.T) {
 	cycleFunc := func() {
 		cycleResult++
 	}
-	assert.PanicsWithError(t, errActorNotFound.Error(), func() {
+	assert.PanicsWithError(t, world.ErrActorNotFound.Error(), func() {
 		world.Register(0, cycleFunc)
 	})
+	_, err := world.TryRegister(0, cycleFunc)
+	assert.ErrorIs(t, err, world.ErrActorNotFound)
 
 	world.Tick()
 	assert.Equal(t, cycleResult, 0)
@@ -60,9 +63,448 @@ func TestTextWorldLook(t *testing.T) {
 	w := newTextWorld()
 	assert.Empty(t, w.Look(0))
 
-	actorId, _ := w.NewActor()
+	actorId, _, _ := w.NewActor()
     assert.Empty(t, w.Look(actorId))
 
     w.actors[actorId].currItemId++
     assert.Empty(t, w.Look(actorId))
 }
+
+func TestTextWorldSession(t *testing.T) {
+	s1, s2 := world.NewSession(), world.NewSession()
+	InitSession(s1)
+	InitSession(s2)
+	assert.NotSame(t, s1.GetSafeWorld(), s2.GetSafeWorld())
+
+	actorId1, _ := s1.NewActor()
+	actorId2, _ := s2.NewActor()
+	assert.Equal(t, actorId1, actorId2)
+	_, err := s1.TryRegister(actorId1, func() {})
+	assert.NoError(t, err)
+
+	s1.Reset()
+	_, err = s1.TryRegister(actorId1, func() {})
+	assert.ErrorIs(t, err, world.ErrActorNotFound)
+	_, err = s2.TryRegister(actorId2, func() {})
+	assert.NoError(t, err)
+}
+
+func TestTextWorldRemoveActor(t *testing.T) {
+	w := newTextWorld()
+	var events []world.LifecycleEvent
+	var eventIds []int
+	w.Lifecycle().Subscribe(func(event world.LifecycleEvent, actorId int) {
+		events = append(events, event)
+		eventIds = append(eventIds, actorId)
+	})
+
+	assert.ErrorIs(t, w.RemoveActor(0), world.ErrActorNotFound)
+
+	actorId1, actions, _ := w.NewActor()
+	actorId2, _, _ := w.NewActor()
+	calls := 0
+	w.Register(actorId1, func() { calls++ })
+	w.Register(actorId1, func() { calls++ })
+	assert.NoError(t, w.RemoveActor(actorId1))
+	assert.NotContains(t, w.actors, actorId1)
+	assert.ErrorIs(t, w.RemoveActor(actorId1), world.ErrActorNotFound)
+
+	w.Tick()
+	assert.Zero(t, calls)
+	for _, action := range actions {
+		assert.False(t, action.Ready())
+	}
+
+	handle, _ := w.Register(actorId2, func() { calls++ })
+	w.Reset()
+	assert.False(t, handle.Active())
+	assert.False(t, handle.Cancel())
+	assert.Equal(t, []world.LifecycleEvent{world.ActorSpawned, world.ActorSpawned, world.ActorRemoved, world.ActorRemoved}, events)
+	assert.Equal(t, []int{actorId1, actorId2, actorId1, actorId2}, eventIds)
+}
+
+func TestTextWorldTickOrder(t *testing.T) {
+	w := newTextWorld()
+	var order []int
+	var actorIds []int
+	for i := 0; i < 10; i++ {
+		actorId, _, _ := w.NewActor()
+		actorIds = append(actorIds, actorId)
+	}
+
+	for i := len(actorIds) - 1; i >= 0; i-- {
+		actorId := actorIds[i]
+		_, err := w.Register(actorId, func() {
+			order = append(order, actorId)
+		})
+		assert.NoError(t, err)
+	}
+
+	last := actorIds[len(actorIds)-1]
+	_, err := w.Register(last, func() {
+		order = append(order, last)
+	}, world.CyclePriority(1), world.CycleName("last"))
+	assert.NoError(t, err)
+
+	var expected []int
+	for i := len(actorIds) - 1; i >= 0; i-- {
+		expected = append(expected, actorIds[i])
+	}
+	expected = append(expected, last)
+
+	for tick := 0; tick < 5; tick++ {
+		order = nil
+		w.Tick()
+		assert.Equal(t, expected, order)
+	}
+}
+
+func TestTextWorldCycleHandles(t *testing.T) {
+	w := newTextWorld()
+	actorId, _, _ := w.NewActor()
+	calls := map[string]int{}
+	perception, err := w.Register(actorId, func() { calls["perception"]++ }, world.CycleName("perception"))
+	assert.NoError(t, err)
+	_, err = w.Register(actorId, func() { calls["planning"]++ }, world.CycleName("planning"))
+	assert.NoError(t, err)
+	_, err = w.Register(actorId, func() {}, world.CycleName("planning"))
+	assert.ErrorIs(t, err, world.ErrCycleExists)
+
+	w.Tick()
+	assert.Equal(t, map[string]int{"perception": 1, "planning": 1}, calls)
+
+	assert.True(t, perception.Cancel())
+	w.Tick()
+	assert.Equal(t, map[string]int{"perception": 1, "planning": 2}, calls)
+}
+
+func TestTextWorldLookDelta(t *testing.T) {
+	w := newTextWorld()
+	actorId, actions, _ := w.NewActor()
+	otherId, otherActions, _ := w.NewActor()
+	f := w.rootDirectory.newFile("fName")
+	other := w.rootDirectory.newFile("other")
+	w.actors[actorId].currItemId = f.id()
+	w.actors[otherId].currItemId = other.id()
+
+	d := w.LookDelta(actorId)
+	assert.Len(t, d.Added, len(w.Look(actorId)))
+	assert.True(t, w.LookDelta(actorId).Empty())
+
+	findAction(actions, "text.pressKey.a").Step()
+	d = w.LookDelta(actorId)
+	assert.Len(t, d.Added, 1)
+	assert.Empty(t, d.Changed)
+	assert.Empty(t, d.Removed)
+
+	// the previously typed character moved relative to the cursor
+	findAction(actions, "text.pressKey.b").Step()
+	d = w.LookDelta(actorId)
+	assert.Len(t, d.Added, 1)
+	assert.Len(t, d.Changed, 1)
+	assert.Equal(t, f.lines[0].characters[0].id, d.Changed[0].Id)
+
+	// edits of another file leave the observation untouched
+	findAction(otherActions, "text.pressKey.c").Step()
+	assert.True(t, w.LookDelta(actorId).Empty())
+
+	// the character before the cursor is removed, the remaining one moved closer to the cursor
+	findAction(actions, "text.specialKey.backspace").Step()
+	d = w.LookDelta(actorId)
+	assert.Len(t, d.Removed, 1)
+	assert.Len(t, d.Changed, 1)
+	assert.Equal(t, f.lines[0].characters[0].id, d.Changed[0].Id)
+
+	assert.NoError(t, w.RemoveActor(actorId))
+	assert.True(t, w.LookDelta(actorId).Empty())
+
+	s := world.NewSession()
+	InitSession(s)
+	assert.Implements(t, (*world.DeltaLooker)(nil), s.GetSafeWorld())
+}
+
+func TestTextWorldStream(t *testing.T) {
+	w := world.Stream(newTextWorld())
+	actorId, actions, _ := w.NewActor()
+	sub, err := w.Subscribe(actorId)
+	assert.NoError(t, err)
+
+	done := make(chan []*world.Observation)
+	go func() {
+		var received []*world.Observation
+		for obs := range sub.C {
+			received = append(received, obs)
+		}
+		done <- received
+	}()
+
+	findAction(actions, "text.changeItem.itemDown").Step()
+	w.Tick()
+	w.Tick()
+	assert.NoError(t, w.RemoveActor(actorId))
+
+	received := <-done
+	assert.Len(t, received, 2)
+	assert.Len(t, received[0].Touches, 2)
+	assert.Len(t, received[1].Touches, 1)
+	assert.True(t, world.IsClockTouch(received[1].Touches[0]))
+	assert.Equal(t, 2, received[1].Tick)
+}
+
+func TestTextWorldRuntime(t *testing.T) {
+	s := world.NewSession()
+	InitSession(s)
+	w := s.GetSafeWorld().(*textWorld)
+	shared := w.rootDirectory.newFile("shared")
+	r := world.NewRuntime(w)
+	defer r.Stop()
+
+	const actorCount, ticks = 4, 20
+	for i := 0; i < actorCount; i++ {
+		actorId, actions, _ := w.NewActor()
+		w.actors[actorId].currItemId = shared.id()
+		keyA := findAction(actions, "text.pressKey.a")
+		assert.NoError(t, r.Spawn(actorId, func() {
+			w.Look(actorId)
+			w.LookDelta(actorId)
+			w.Feel(actorId)
+			keyA.Why()
+			keyA.Step()
+		}))
+	}
+
+	assert.NoError(t, r.Run(ticks))
+	assert.Len(t, shared.lines[0].characters, actorCount*ticks)
+}
+
+func TestTextWorldClock(t *testing.T) {
+	w := newTextWorld()
+	actorId, _, _ := w.NewActor()
+	assert.Zero(t, w.Clock())
+	assert.Empty(t, w.Feel(actorId))
+
+	w.Tick()
+	w.Tick()
+	assert.Equal(t, 2, w.Clock())
+	for _, img := range w.Look(actorId) {
+		assert.Equal(t, 2, img.Time)
+	}
+
+	tchs := w.Feel(actorId)
+	assert.Len(t, tchs, 1)
+	assert.Contains(t, tchs[0].Info.Labels, world.InfoLabelClock)
+	assert.Equal(t, 2, tchs[0].Info.Value)
+	assert.Empty(t, w.Feel(actorId))
+
+	// the clock alone does not make the observation change
+	w.LookDelta(actorId)
+	w.Tick()
+	assert.True(t, w.LookDelta(actorId).Empty())
+
+	w.Reset()
+	assert.Zero(t, w.Clock())
+}
+
+func TestTextWorldVocabulary(t *testing.T) {
+	tw := newTextWorld()
+	var reported []error
+	w := world.Debug(tw, tw.Vocabulary(), func(err error) {
+		reported = append(reported, err)
+	})
+
+	actorId, actions, _ := w.NewActor()
+	tw.rootDirectory.newDirectory("dName")
+	f := tw.rootDirectory.newFile("fName")
+	w.Look(actorId)
+	findAction(actions, "text.changeItem.itemDown").Step()
+	findAction(actions, "text.changeItem.itemEnter").Step()
+	assert.Equal(t, f.id(), tw.actors[actorId].currItemId)
+	for _, id := range []string{"text.pressKey.a", "text.pressKey.b", "text.specialKey.enter", "text.pressKey.c", "text.specialKey.left"} {
+		findAction(actions, id).Step()
+	}
+
+	findAction(actions, "text.specialKey.left").Step()
+	w.Tick()
+	assert.NotEmpty(t, w.Look(actorId))
+	assert.NotEmpty(t, w.Feel(actorId))
+	assert.Empty(t, reported)
+	assert.Contains(t, world.Ontology(), tw.Vocabulary())
+}
+
+func TestTextWorldSnapshot(t *testing.T) {
+	s := world.NewSession()
+	w := newSessionTextWorld(s)
+	actorId, actions, _ := w.NewActor()
+	f := w.rootDirectory.newFile("fName")
+	w.actors[actorId].currItemId = f.id()
+	findAction(actions, "text.pressKey.a").Step()
+	w.Tick()
+
+	snapshot, err := w.Snapshot()
+	assert.NoError(t, err)
+	assert.Equal(t, "text", snapshot.World)
+	saved, unitId := w.Look(actorId), s.LastUnitId()
+
+	var events []world.LifecycleEvent
+	w.Lifecycle().Subscribe(func(event world.LifecycleEvent, _ int) {
+		events = append(events, event)
+	})
+
+	var branchIds []int
+	for i := 0; i < 2; i++ {
+		findAction(actions, "text.pressKey.b").Step()
+		w.rootDirectory.newFile("other")
+		otherId, _, _ := w.NewActor()
+		branchIds = append(branchIds, otherId)
+		w.Tick()
+		w.Feel(actorId)
+
+		assert.NoError(t, w.Restore(snapshot))
+		assert.Equal(t, 1, w.Clock())
+		assert.Equal(t, unitId, s.LastUnitId())
+		assert.Equal(t, saved, w.Look(actorId))
+		assert.Len(t, w.Feel(actorId), 2)
+		assert.NotContains(t, w.actors, otherId)
+		assert.Len(t, w.rootDirectory.content, 1)
+	}
+
+	// both branches handed out the same ids
+	assert.Equal(t, branchIds[0], branchIds[1])
+	assert.Equal(t, []world.LifecycleEvent{
+		world.ActorSpawned, world.ActorRemoved, world.ActorSpawned, world.ActorRemoved,
+	}, events)
+
+	// the restored tree is a copy, the actions handed out before the snapshot edit it
+	restored := w.items[f.id()].(*file)
+	assert.NotSame(t, f, restored)
+	findAction(actions, "text.pressKey.c").Step()
+	assert.Equal(t, "ac", lineString(restored.lines[0]))
+	assert.Equal(t, "ab", lineString(f.lines[0]))
+	assert.False(t, w.LookDelta(actorId).Empty())
+
+	other, _ := newSessionTextWorld(world.NewSession()).Snapshot()
+	assert.ErrorIs(t, w.Restore(other), world.ErrSnapshotMismatch)
+	assert.ErrorIs(t, w.Restore(nil), world.ErrInvalidArgs)
+}
+
+func TestTextWorldRestoreAfterReset(t *testing.T) {
+	w := newSessionTextWorld(world.NewSession())
+	_, actions, _ := w.NewActor()
+	assert.NoError(t, w.Cmd(CmdNewFile, "fName"))
+	assert.NoError(t, w.Cmd(CmdSetCooldown, "text.pressKey.a", 2))
+	findAction(actions, "text.changeItem.itemEnter").Step()
+	snapshot, _ := w.Snapshot()
+
+	// the restored actions are still guarded by the scheduler the world keeps ticking
+	w.Reset()
+	assert.NoError(t, w.Restore(snapshot))
+	keyA := findAction(actions, "text.pressKey.a")
+	assert.Equal(t, world.OutcomeSuccess, keyA.Step().Status)
+	assert.ErrorIs(t, keyA.Why(), world.ErrCooldown)
+	w.Tick()
+	w.Tick()
+	assert.NoError(t, keyA.Why())
+}
+
+func TestTextWorldDigest(t *testing.T) {
+	build := func() *textWorld {
+		w := newSessionTextWorld(world.NewSession())
+		actorId, actions, _ := w.NewActor()
+		w.actors[actorId].currItemId = w.rootDirectory.newFile("fName").id()
+		findAction(actions, "text.pressKey.a").Step()
+		return w
+	}
+
+	w1, w2 := build(), build()
+	d1, err := w1.Digest()
+	assert.NoError(t, err)
+	d2, _ := w2.Digest()
+	assert.Equal(t, d1, d2)
+
+	snapshot, _ := w1.Snapshot()
+	actions, _ := w1.Actions(w1.actorIds()[0])
+	findAction(actions, "text.pressKey.b").Step()
+	changed, _ := w1.Digest()
+	assert.NotEqual(t, d1, changed)
+
+	assert.NoError(t, w1.Restore(snapshot))
+	restored, _ := w1.Digest()
+	assert.Equal(t, d1, restored)
+}
+
+func TestTextWorldDigestTiming(t *testing.T) {
+	// each pair has the same tree, cursors and touches, only what decides future outcomes differs
+	digest := func(setup func(w *textWorld, actions []*world.ActionInterface)) world.Digest {
+		w := newSessionTextWorld(world.NewSession())
+		actorId, actions, _ := w.NewActor()
+		w.actors[actorId].currItemId = w.rootDirectory.newFile("fName").id()
+		setup(w, actions)
+		result, _ := w.Digest()
+		return result
+	}
+
+	for name, pair := range map[string][2]func(w *textWorld, actions []*world.ActionInterface){
+		"timings": {
+			func(w *textWorld, actions []*world.ActionInterface) {},
+			func(w *textWorld, actions []*world.ActionInterface) { w.Cmd(CmdSetCooldown, "pressKey", 2) },
+		},
+		"budget": {
+			func(w *textWorld, actions []*world.ActionInterface) {},
+			func(w *textWorld, actions []*world.ActionInterface) { w.Cmd(CmdSetBudget, 1) },
+		},
+		"busy": {
+			func(w *textWorld, actions []*world.ActionInterface) {
+				w.Cmd(CmdSetDuration, "pressKey", 2)
+				findAction(actions, "text.pressKey.a").Step()
+				w.Feel(w.actorIds()[0])
+			},
+			func(w *textWorld, actions []*world.ActionInterface) {
+				w.Cmd(CmdSetDuration, "pressKey", 2)
+				findAction(actions, "text.pressKey.b").Step()
+				w.Feel(w.actorIds()[0])
+			},
+		},
+		"intents": {
+			func(w *textWorld, actions []*world.ActionInterface) {
+				w.Cmd(CmdSetSimultaneous, true)
+				findAction(actions, "text.typeChar").StepWith("a")
+			},
+			func(w *textWorld, actions []*world.ActionInterface) {
+				w.Cmd(CmdSetSimultaneous, true)
+				findAction(actions, "text.typeChar").StepWith("b")
+			},
+		},
+	} {
+		assert.Equal(t, digest(pair[0]), digest(pair[0]), name)
+		assert.NotEqual(t, digest(pair[0]), digest(pair[1]), name)
+	}
+}
+
+func TestTextWorldDeterminism(t *testing.T) {
+	episode := func(seed int64) (world.SafeWorld, error) {
+		s := world.NewSession()
+		InitSession(s)
+		w := s.GetSafeWorld().(*textWorld)
+		w.rootDirectory.newFile("shared")
+		w.rootDirectory.newDirectory("dName")
+		rng := rand.New(rand.NewSource(seed))
+		for i := 0; i < 3; i++ {
+			actorId, actions, err := w.NewActor()
+			if err != nil {
+				return nil, err
+			}
+
+			if _, err = w.Register(actorId, func() {
+				actions[rng.Intn(len(actions))].Step()
+			}); err != nil {
+				return nil, err
+			}
+		}
+
+		return w, nil
+	}
+
+	for seed := int64(0); seed < 5; seed++ {
+		assert.NoError(t, world.CheckDeterminism(seed, 50, episode))
+	}
+}
@@ -0,0 +1,349 @@
+// Package trace records what agents saw and did in a world as a JSON-lines trace.
+package trace
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	world "github.com/sapphire-ai-dev/sapphire-world"
+	"github.com/sapphire-ai-dev/sapphire-world/wire"
+)
+
+// event kinds, one per recorded world call
+const (
+	KindReset       = "reset"
+	KindTick        = "tick"
+	KindNewActor    = "newActor"
+	KindRemoveActor = "removeActor"
+	KindRegister    = "register"
+	KindStep        = "step"
+	KindLook        = "look"
+	KindFeel        = "feel"
+	KindCmd         = "cmd"
+	KindSnapshot    = "snapshot"
+	KindRestore     = "restore"
+)
+
+/*
+Event
+
+	# a single line of a trace
+
+	# fields:
+		# Seq: position of the event in the trace, starting at 1
+		# Tick: the world clock when the call returned, a tick event closes the tick it names
+		# Kind: which world call was made
+		# ActorId: the actor the call was made for, 0 for world-wide calls
+		# Action: id of the stepped action
+		# Cycle: name of the registered cycle function
+		# Args: arguments of NewActor, Cmd and StepWith, arguments that cannot be encoded are recorded as their %v string
+		# Outcome: result of a step
+		# Message: wire encoded images of a look, touches of a feel or action descriptions of a new actor
+		# Error: error returned by the call
+*/
+type Event struct {
+	Seq     int               `json:"seq"`
+	Tick    int               `json:"tick"`
+	Kind    string            `json:"kind"`
+	ActorId int               `json:"actorId"`
+	Action  string            `json:"action,omitempty"`
+	Cycle   string            `json:"cycle,omitempty"`
+	Args    []json.RawMessage `json:"args,omitempty"`
+	Outcome *Outcome          `json:"outcome,omitempty"`
+	Message json.RawMessage   `json:"message,omitempty"`
+	Error   string            `json:"error,omitempty"`
+}
+
+type Outcome struct {
+	Status string `json:"status"`
+	Reason string `json:"reason,omitempty"`
+}
+
+/*
+Recorder
+
+	# wraps any SafeWorld, i.e. the text or adaptor world, writing an event per call to out
+	# action interfaces handed out by NewActor and Actions are wrapped to record their steps
+	# the first write failure stops recording and is reported by Err
+*/
+type Recorder struct {
+	world.SafeWorld
+	mu      sync.Mutex
+	out     io.Writer
+	closer  io.Closer
+	seq     int
+	err     error
+	actions map[int][]*world.ActionInterface // actorId -> recording action interfaces
+}
+
+func Record(w world.SafeWorld, out io.Writer) *Recorder {
+	return &Recorder{
+		SafeWorld: w,
+		out:       out,
+		actions:   map[int][]*world.ActionInterface{},
+	}
+}
+
+// Create records into a new trace file at path, Close closes it
+func Create(w world.SafeWorld, path string) (*Recorder, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+
+	result := Record(w, f)
+	result.closer = f
+	return result, nil
+}
+
+func (r *Recorder) Unwrap() world.SafeWorld {
+	return r.SafeWorld
+}
+
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closer == nil {
+		return r.err
+	}
+
+	if err := r.closer.Close(); err != nil && r.err == nil {
+		r.err = err
+	}
+
+	r.closer = nil
+	return r.err
+}
+
+func (r *Recorder) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+func (r *Recorder) emit(event *Event) {
+	event.Tick = r.SafeWorld.Clock()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return
+	}
+
+	r.seq++
+	event.Seq = r.seq
+	line, err := json.Marshal(event)
+	if err == nil {
+		_, err = r.out.Write(append(line, '\n'))
+	}
+
+	r.err = err
+}
+
+func encodeArgs(args []any) []json.RawMessage {
+	var result []json.RawMessage
+	for _, arg := range args {
+		encoded, err := json.Marshal(arg)
+		if err != nil {
+			encoded, _ = json.Marshal(fmt.Sprintf("%v", arg))
+		}
+
+		result = append(result, encoded)
+	}
+
+	return result
+}
+
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	return err.Error()
+}
+
+// encodeMessage never fails the recorded call, encoding failures are recorded as the event error instead
+func encodeMessage(event *Event, m *wire.Message) {
+	encoded, err := wire.MarshalJSON(m)
+	if err != nil {
+		event.Error = err.Error()
+		return
+	}
+
+	event.Message = encoded
+}
+
+func (r *Recorder) Reset() {
+	r.SafeWorld.Reset()
+	r.mu.Lock()
+	r.actions = map[int][]*world.ActionInterface{}
+	r.mu.Unlock()
+	r.emit(&Event{Kind: KindReset})
+}
+
+func (r *Recorder) Tick() {
+	r.SafeWorld.Tick()
+	r.emit(&Event{Kind: KindTick})
+}
+
+func (r *Recorder) NewActor(args ...any) (int, []*world.ActionInterface, error) {
+	actorId, actions, err := r.SafeWorld.NewActor(args...)
+	event := &Event{Kind: KindNewActor, ActorId: actorId, Args: encodeArgs(args), Error: errString(err)}
+	if err != nil {
+		r.emit(event)
+		return actorId, actions, err
+	}
+
+	var recording []*world.ActionInterface
+	var descriptions []*world.ActionDescription
+	for _, action := range actions {
+		recording = append(recording, r.wrap(actorId, action))
+		descriptions = append(descriptions, action.Describe())
+	}
+
+	r.mu.Lock()
+	r.actions[actorId] = recording
+	r.mu.Unlock()
+
+	encodeMessage(event, &wire.Message{Actions: descriptions})
+	r.emit(event)
+	return actorId, recording, nil
+}
+
+// wrap copies the action interface, recording every step before returning its outcome
+func (r *Recorder) wrap(actorId int, action *world.ActionInterface) *world.ActionInterface {
+	result := *action
+	result.Step = func() *world.Outcome {
+		outcome := action.Step()
+		r.emitStep(actorId, action, nil, outcome)
+		return outcome
+	}
+
+	if action.StepWith != nil {
+		result.StepWith = func(args ...any) *world.Outcome {
+			outcome := action.StepWith(args...)
+			r.emitStep(actorId, action, args, outcome)
+			return outcome
+		}
+	}
+
+	return &result
+}
+
+func (r *Recorder) emitStep(actorId int, action *world.ActionInterface, args []any, outcome *world.Outcome) {
+	event := &Event{Kind: KindStep, ActorId: actorId, Action: action.Id, Args: encodeArgs(args)}
+	if outcome != nil {
+		event.Outcome = &Outcome{Status: outcome.Status.Label(), Reason: outcome.Reason}
+	}
+
+	r.emit(event)
+}
+
+func (r *Recorder) RemoveActor(actorId int) error {
+	err := r.SafeWorld.RemoveActor(actorId)
+	r.mu.Lock()
+	delete(r.actions, actorId)
+	r.mu.Unlock()
+	r.emit(&Event{Kind: KindRemoveActor, ActorId: actorId, Error: errString(err)})
+	return err
+}
+
+func (r *Recorder) Actions(actorId int) ([]*world.ActionInterface, error) {
+	if _, err := r.SafeWorld.Actions(actorId); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.actions[actorId], nil
+}
+
+func (r *Recorder) Register(actorId int, cycle func(), opts ...world.CycleOption) (*world.CycleHandle, error) {
+	handle, err := r.SafeWorld.Register(actorId, cycle, opts...)
+	event := &Event{Kind: KindRegister, ActorId: actorId, Error: errString(err)}
+	if handle != nil {
+		event.Cycle = handle.Name()
+	}
+
+	r.emit(event)
+	return handle, err
+}
+
+// recorderState wraps the snapshot of the recorded world with the recording action interfaces
+type recorderState struct {
+	owner   *Recorder
+	inner   *world.Snapshot
+	actions map[int][]*world.ActionInterface
+}
+
+func (r *Recorder) Snapshot() (*world.Snapshot, error) {
+	inner, err := r.SafeWorld.Snapshot()
+	r.emit(&Event{Kind: KindSnapshot, Error: errString(err)})
+	if err != nil {
+		return nil, err
+	}
+
+	state := &recorderState{owner: r, inner: inner, actions: map[int][]*world.ActionInterface{}}
+	r.mu.Lock()
+	for actorId, actions := range r.actions {
+		state.actions[actorId] = actions
+	}
+	r.mu.Unlock()
+
+	return &world.Snapshot{World: inner.World, Clock: inner.Clock, UnitId: inner.UnitId, State: state}, nil
+}
+
+func (r *Recorder) Restore(snapshot *world.Snapshot) error {
+	err := r.restore(snapshot)
+	r.emit(&Event{Kind: KindRestore, Error: errString(err)})
+	return err
+}
+
+func (r *Recorder) restore(snapshot *world.Snapshot) error {
+	if snapshot == nil {
+		return world.ErrInvalidArgs
+	}
+
+	state, ok := snapshot.State.(*recorderState)
+	if !ok || state.owner != r {
+		return world.ErrSnapshotMismatch
+	}
+
+	if err := r.SafeWorld.Restore(state.inner); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.actions = map[int][]*world.ActionInterface{}
+	for actorId, actions := range state.actions {
+		r.actions[actorId] = actions
+	}
+
+	return nil
+}
+
+func (r *Recorder) Look(actorId int) []*world.Image {
+	result := r.SafeWorld.Look(actorId)
+	event := &Event{Kind: KindLook, ActorId: actorId}
+	encodeMessage(event, &wire.Message{Images: result})
+	r.emit(event)
+	return result
+}
+
+func (r *Recorder) Feel(actorId int) []*world.Touch {
+	result := r.SafeWorld.Feel(actorId)
+	event := &Event{Kind: KindFeel, ActorId: actorId}
+	encodeMessage(event, &wire.Message{Touches: result})
+	r.emit(event)
+	return result
+}
+
+func (r *Recorder) Cmd(args ...any) error {
+	err := r.SafeWorld.Cmd(args...)
+	r.emit(&Event{Kind: KindCmd, Args: encodeArgs(args), Error: errString(err)})
+	return err
+}
@@ -0,0 +1,132 @@
+package trace
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	world "github.com/sapphire-ai-dev/sapphire-world"
+	"github.com/sapphire-ai-dev/sapphire-world/adaptor"
+	"github.com/sapphire-ai-dev/sapphire-world/text"
+	"github.com/sapphire-ai-dev/sapphire-world/wire"
+	"github.com/stretchr/testify/assert"
+)
+
+func textWorld() world.SafeWorld {
+	s := world.NewSession()
+	text.InitSession(s)
+	return s.GetSafeWorld()
+}
+
+func readEvents(t *testing.T, r io.Reader) []*Event {
+	var result []*Event
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		event := &Event{}
+		assert.NoError(t, json.Unmarshal(scanner.Bytes(), event))
+		result = append(result, event)
+	}
+
+	return result
+}
+
+func findAction(actions []*world.ActionInterface, id string) *world.ActionInterface {
+	for _, action := range actions {
+		if action.Id == id {
+			return action
+		}
+	}
+
+	return nil
+}
+
+func TestRecorder(t *testing.T) {
+	out := &bytes.Buffer{}
+	r := Record(textWorld(), out)
+	actorId, actions, err := r.NewActor()
+	assert.NoError(t, err)
+	listed, _ := r.Actions(actorId)
+	assert.Equal(t, actions, listed)
+
+	_, err = r.Register(actorId, func() {
+		findAction(actions, "text.changeItem.itemDown").Step()
+	}, world.CycleName("agent"))
+	assert.NoError(t, err)
+	findAction(actions, "text.typeChar").StepWith("a")
+	r.Tick()
+	r.Look(actorId)
+	r.Feel(actorId)
+	assert.ErrorIs(t, r.Cmd(-1), world.ErrInvalidArgs)
+	assert.NoError(t, r.RemoveActor(actorId))
+	assert.NoError(t, r.Err())
+
+	events := readEvents(t, out)
+	var kinds []string
+	for i, event := range events {
+		assert.Equal(t, i+1, event.Seq)
+		kinds = append(kinds, event.Kind)
+	}
+
+	assert.Equal(t, []string{
+		KindNewActor, KindRegister, KindStep, KindStep, KindTick, KindLook, KindFeel, KindCmd, KindRemoveActor,
+	}, kinds)
+
+	newActor, _ := wire.UnmarshalJSON(events[0].Message)
+	assert.Len(t, newActor.Actions, len(actions))
+	assert.Equal(t, actorId, events[0].ActorId)
+	assert.Equal(t, "agent", events[1].Cycle)
+
+	assert.Equal(t, "text.typeChar", events[2].Action)
+	assert.Equal(t, []json.RawMessage{json.RawMessage(`"a"`)}, events[2].Args)
+	assert.Equal(t, world.OutcomeRejected.Label(), events[2].Outcome.Status)
+	assert.Zero(t, events[2].Tick)
+
+	// steps taken by cycle functions are recorded inside their tick
+	assert.Equal(t, "text.changeItem.itemDown", events[3].Action)
+	assert.Equal(t, 1, events[3].Tick)
+	assert.Equal(t, 1, events[4].Tick)
+
+	feel, _ := wire.UnmarshalJSON(events[6].Message)
+	assert.Len(t, feel.Touches, 3)
+	assert.Equal(t, world.ErrInvalidArgs.Error(), events[7].Error)
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write(_ []byte) (int, error) {
+	return 0, os.ErrClosed
+}
+
+func TestRecorderWriteFailure(t *testing.T) {
+	r := Record(textWorld(), failingWriter{})
+	r.Tick()
+	r.Tick()
+	assert.ErrorIs(t, r.Err(), os.ErrClosed)
+}
+
+func TestRecorderFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trace.jsonl")
+	s := world.NewSession()
+	text.InitSession(s)
+	b := adaptor.InitStartSession(s)
+	_, err := b.TryProxy()
+	assert.NoError(t, err)
+	assert.NoError(t, b.TryInitComplete())
+
+	r, err := Create(s.GetSafeWorld(), path)
+	assert.NoError(t, err)
+	s.SetSafeWorld(r)
+	actorId, _ := s.NewActor()
+	s.Tick()
+	s.Look(actorId)
+	assert.NoError(t, r.Close())
+
+	f, err := os.Open(path)
+	assert.NoError(t, err)
+	defer f.Close()
+	assert.Len(t, readEvents(t, f), 3)
+}
@@ -0,0 +1,450 @@
+package trace
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	world "github.com/sapphire-ai-dev/sapphire-world"
+	"github.com/sapphire-ai-dev/sapphire-world/wire"
+)
+
+var (
+	ErrDiverged  = errors.New("replay diverged from trace")
+	ErrNoOutcome = errors.New("recorded step has no outcome")
+)
+
+// Read decodes a trace written by a Recorder
+func Read(r io.Reader) ([]*Event, error) {
+	var result []*Event
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 64<<20)
+	for scanner.Scan() {
+		event := &Event{}
+		if err := json.Unmarshal(scanner.Bytes(), event); err != nil {
+			return nil, fmt.Errorf("trace line %d: %w", len(result)+1, err)
+		}
+
+		result = append(result, event)
+	}
+
+	return result, scanner.Err()
+}
+
+func Load(path string) ([]*Event, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+	return Read(f)
+}
+
+/*
+Divergence
+
+	# the first call of a replayed agent that does not match the trace
+	# Expected is nil if the agent made more calls than recorded, Actual is nil if it made fewer
+*/
+type Divergence struct {
+	Expected *Event
+	Actual   *Event
+}
+
+func (d *Divergence) Error() string {
+	if d.Expected == nil {
+		return fmt.Sprintf("%v: unexpected %s of actor %d after the end of the trace", ErrDiverged, d.Actual.Kind, d.Actual.ActorId)
+	}
+
+	if d.Actual == nil {
+		return fmt.Sprintf("%v: trace event %d (%s of actor %d) never happened", ErrDiverged, d.Expected.Seq, d.Expected.Kind, d.Expected.ActorId)
+	}
+
+	return fmt.Sprintf("%v at trace event %d: expected %s, got %s", ErrDiverged, d.Expected.Seq, describe(d.Expected), describe(d.Actual))
+}
+
+func (d *Divergence) Unwrap() error {
+	return ErrDiverged
+}
+
+func describe(event *Event) string {
+	result := fmt.Sprintf("%s of actor %d", event.Kind, event.ActorId)
+	if event.Action != "" {
+		result += " " + event.Action
+	}
+
+	if event.Cycle != "" {
+		result += " cycle " + event.Cycle
+	}
+
+	if len(event.Args) > 0 {
+		args, _ := json.Marshal(event.Args)
+		result += " with " + string(args)
+	}
+
+	return result
+}
+
+/*
+Replay
+
+	# a SafeWorld serving the observations of a recorded trace to an agent
+	# every call of the agent is checked against the next event of the trace, the first mismatch is kept as a Divergence
+	# after a divergence calls return zero values and action steps are rejected with ErrDiverged
+	# Ready reports true for every action, only the recorded steps are checked
+	# Snapshot and Restore only save the clock and the actors, observations after a Restore are still served in trace order
+	# cycle functions run on Tick, before the tick event is matched, as the recorded world ran them
+	# arguments that could not be encoded when recording, i.e. functions passed to Cmd, cannot be matched
+*/
+type Replay struct {
+	mu        sync.Mutex
+	events    []*Event
+	next      int
+	err       *Divergence
+	clock     int
+	cycles    *world.CycleRegistry
+	actions   map[int][]*world.ActionInterface
+	lifecycle *world.Lifecycle
+}
+
+func NewReplay(events []*Event) *Replay {
+	return &Replay{
+		events:    events,
+		cycles:    world.NewCycleRegistry(),
+		actions:   map[int][]*world.ActionInterface{},
+		lifecycle: world.NewLifecycle(),
+	}
+}
+
+// match consumes the next event if the call matches it, returning nil on divergence
+func (r *Replay) match(actual *Event) *Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return nil
+	}
+
+	if r.next >= len(r.events) {
+		r.err = &Divergence{Actual: actual}
+		return nil
+	}
+
+	expected := r.events[r.next]
+	if !sameCall(expected, actual) {
+		r.err = &Divergence{Expected: expected, Actual: actual}
+		return nil
+	}
+
+	r.next++
+	return expected
+}
+
+// the actor id of a new actor is chosen by the world, so it is served from the trace rather than matched
+func sameCall(expected, actual *Event) bool {
+	if actual.Kind == KindNewActor {
+		actual.ActorId = expected.ActorId
+	}
+
+	if expected.Kind != actual.Kind || expected.ActorId != actual.ActorId ||
+		expected.Action != actual.Action || expected.Cycle != actual.Cycle ||
+		len(expected.Args) != len(actual.Args) {
+		return false
+	}
+
+	for i := range expected.Args {
+		if !bytes.Equal(expected.Args[i], actual.Args[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Err returns the first divergence, or nil if the agent followed the trace so far
+func (r *Replay) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err == nil {
+		return nil
+	}
+
+	return r.err
+}
+
+// Verify returns the first divergence, including trace events the agent never got to
+func (r *Replay) Verify() error {
+	if err := r.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.next < len(r.events) {
+		return &Divergence{Expected: r.events[r.next]}
+	}
+
+	return nil
+}
+
+func recordedErr(event *Event) error {
+	if event == nil {
+		return ErrDiverged
+	}
+
+	if event.Error == "" {
+		return nil
+	}
+
+	return errors.New(event.Error)
+}
+
+func decode(event *Event) *wire.Message {
+	if event == nil || len(event.Message) == 0 {
+		return &wire.Message{}
+	}
+
+	result, err := wire.UnmarshalJSON(event.Message)
+	if err != nil {
+		return &wire.Message{}
+	}
+
+	return result
+}
+
+func (r *Replay) Name() string {
+	return "replay"
+}
+
+func (r *Replay) Reset() {
+	r.match(&Event{Kind: KindReset})
+	r.mu.Lock()
+	r.clock = 0
+	r.cycles.Clear()
+	r.actions = map[int][]*world.ActionInterface{}
+	r.mu.Unlock()
+}
+
+func (r *Replay) Tick() {
+	r.mu.Lock()
+	r.clock++
+	cycles := r.cycles
+	r.mu.Unlock()
+
+	cycles.Run()
+	r.match(&Event{Kind: KindTick})
+}
+
+func (r *Replay) Clock() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.clock
+}
+
+func (r *Replay) NewActor(args ...any) (int, []*world.ActionInterface, error) {
+	event := r.match(&Event{Kind: KindNewActor, Args: encodeArgs(args)})
+	if event == nil {
+		return 0, nil, ErrDiverged
+	}
+
+	if event.Error != "" {
+		return 0, nil, recordedErr(event)
+	}
+
+	var actions []*world.ActionInterface
+	for _, description := range decode(event).Actions {
+		actions = append(actions, r.replayAction(event.ActorId, description))
+	}
+
+	r.mu.Lock()
+	r.actions[event.ActorId] = actions
+	r.mu.Unlock()
+
+	r.lifecycle.Emit(world.ActorSpawned, event.ActorId)
+	return event.ActorId, actions, nil
+}
+
+func (r *Replay) replayAction(actorId int, description *world.ActionDescription) *world.ActionInterface {
+	result := &world.ActionInterface{
+		Name:        description.Name,
+		Id:          description.Id,
+		Category:    description.Category,
+		Description: description.Description,
+		World:       description.World,
+		Schema:      description.Schema,
+		Duration:    description.Duration,
+		Cooldown:    description.Cooldown,
+		Shorthand:   description.Shorthand,
+		Ready:       func() bool { return true },
+		Step: func() *world.Outcome {
+			return r.step(actorId, description.Id, nil)
+		},
+	}
+
+	if description.Parameterized {
+		result.StepWith = func(args ...any) *world.Outcome {
+			return r.step(actorId, description.Id, args)
+		}
+	}
+
+	return result
+}
+
+func (r *Replay) step(actorId int, actionId string, args []any) *world.Outcome {
+	event := r.match(&Event{Kind: KindStep, ActorId: actorId, Action: actionId, Args: encodeArgs(args)})
+	if event == nil {
+		return world.Rejected(ErrDiverged.Error())
+	}
+
+	// the recorded action reported no outcome, the replay reports it as rejected rather than handing out nil
+	if event.Outcome == nil {
+		return world.Rejected(ErrNoOutcome.Error())
+	}
+
+	return &world.Outcome{Status: outcomeStatusOf(event.Outcome.Status), Reason: event.Outcome.Reason}
+}
+
+func outcomeStatusOf(label string) world.OutcomeStatus {
+	for status := world.OutcomeSuccess; status <= world.OutcomeQueued; status++ {
+		if status.Label() == label {
+			return status
+		}
+	}
+
+	return world.OutcomeRejected
+}
+
+func (r *Replay) Register(actorId int, cycle func(), opts ...world.CycleOption) (*world.CycleHandle, error) {
+	r.mu.Lock()
+	cycles := r.cycles
+	r.mu.Unlock()
+
+	handle, err := cycles.Register(actorId, cycle, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	event := r.match(&Event{Kind: KindRegister, ActorId: actorId, Cycle: handle.Name()})
+	if err = recordedErr(event); err != nil {
+		handle.Cancel()
+		return nil, err
+	}
+
+	return handle, nil
+}
+
+func (r *Replay) Look(actorId int) []*world.Image {
+	return decode(r.match(&Event{Kind: KindLook, ActorId: actorId})).Images
+}
+
+func (r *Replay) Feel(actorId int) []*world.Touch {
+	return decode(r.match(&Event{Kind: KindFeel, ActorId: actorId})).Touches
+}
+
+func (r *Replay) Cmd(args ...any) error {
+	return recordedErr(r.match(&Event{Kind: KindCmd, Args: encodeArgs(args)}))
+}
+
+func (r *Replay) RemoveActor(actorId int) error {
+	event := r.match(&Event{Kind: KindRemoveActor, ActorId: actorId})
+	r.mu.Lock()
+	delete(r.actions, actorId)
+	cycles := r.cycles
+	r.mu.Unlock()
+
+	cycles.RemoveActor(actorId)
+	if err := recordedErr(event); err != nil {
+		return err
+	}
+
+	r.lifecycle.Emit(world.ActorRemoved, actorId)
+	return nil
+}
+
+// replayState saves the clock and the actors of a replay, the observations are served from the trace regardless
+type replayState struct {
+	owner   *Replay
+	actions map[int][]*world.ActionInterface
+}
+
+func (r *Replay) Snapshot() (*world.Snapshot, error) {
+	event := r.match(&Event{Kind: KindSnapshot})
+	if event == nil || event.Error != "" {
+		return nil, recordedErr(event)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	state := &replayState{owner: r, actions: map[int][]*world.ActionInterface{}}
+	for actorId, actions := range r.actions {
+		state.actions[actorId] = actions
+	}
+
+	return &world.Snapshot{World: r.Name(), Clock: r.clock, State: state}, nil
+}
+
+func (r *Replay) Restore(snapshot *world.Snapshot) error {
+	event := r.match(&Event{Kind: KindRestore})
+	if event == nil || event.Error != "" {
+		return recordedErr(event)
+	}
+
+	if snapshot == nil {
+		return world.ErrInvalidArgs
+	}
+
+	state, ok := snapshot.State.(*replayState)
+	if !ok || state.owner != r {
+		return world.ErrSnapshotMismatch
+	}
+
+	r.mu.Lock()
+	before, after := map[int]bool{}, map[int]bool{}
+	for actorId := range r.actions {
+		before[actorId] = true
+	}
+
+	r.actions = map[int][]*world.ActionInterface{}
+	for actorId, actions := range state.actions {
+		after[actorId] = true
+		r.actions[actorId] = actions
+	}
+
+	cycles := r.cycles
+	r.clock = snapshot.Clock
+	r.mu.Unlock()
+
+	for actorId := range before {
+		if !after[actorId] {
+			cycles.RemoveActor(actorId)
+		}
+	}
+
+	r.lifecycle.EmitRestored(before, after)
+	return nil
+}
+
+// Digest is unsupported, a replay serves recorded observations without holding the state they were taken from
+func (r *Replay) Digest() (world.Digest, error) {
+	return world.Digest{}, world.ErrUnsupported
+}
+
+func (r *Replay) Lifecycle() *world.Lifecycle {
+	return r.lifecycle
+}
+
+func (r *Replay) Actions(actorId int) ([]*world.ActionInterface, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	actions, seen := r.actions[actorId]
+	if !seen {
+		return nil, world.ErrActorNotFound
+	}
+
+	return actions, nil
+}
@@ -0,0 +1,179 @@
+package trace
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	world "github.com/sapphire-ai-dev/sapphire-world"
+	"github.com/stretchr/testify/assert"
+)
+
+type episode struct {
+	outcomes []*world.Outcome
+	touches  []*world.Touch
+}
+
+// runAgent plays a short episode, typing the char returned by choose on every tick
+func runAgent(t *testing.T, w world.SafeWorld, ticks int, choose func(tick int) string) *episode {
+	actorId, actions, err := w.NewActor()
+	assert.NoError(t, err)
+
+	result := &episode{}
+	tick := 0
+	_, err = w.Register(actorId, func() {
+		w.Look(actorId)
+		result.touches = append(result.touches, w.Feel(actorId)...)
+		result.outcomes = append(result.outcomes, findAction(actions, "text.typeChar").StepWith(choose(tick)))
+		tick++
+	}, world.CycleName("agent"))
+	assert.NoError(t, err)
+
+	for i := 0; i < ticks; i++ {
+		w.Tick()
+	}
+
+	w.RemoveActor(actorId)
+	return result
+}
+
+func typeA(_ int) string {
+	return "a"
+}
+
+func recordEpisode(t *testing.T) ([]*Event, *episode) {
+	out := &bytes.Buffer{}
+	r := Record(textWorld(), out)
+	recorded := runAgent(t, r, 3, typeA)
+	assert.NoError(t, r.Err())
+
+	events, err := Read(out)
+	assert.NoError(t, err)
+	return events, recorded
+}
+
+func TestReplay(t *testing.T) {
+	events, recorded := recordEpisode(t)
+	r := NewReplay(events)
+	replayed := runAgent(t, r, 3, typeA)
+	assert.NoError(t, r.Verify())
+	assert.Equal(t, 3, r.Clock())
+	assert.Equal(t, recorded.outcomes, replayed.outcomes)
+	assert.NotEmpty(t, replayed.touches)
+	assert.Equal(t, recorded.touches, replayed.touches)
+}
+
+func TestReplayDivergence(t *testing.T) {
+	events, _ := recordEpisode(t)
+	r := NewReplay(events)
+	replayed := runAgent(t, r, 3, func(tick int) string {
+		if tick == 1 {
+			return "b"
+		}
+
+		return "a"
+	})
+
+	var divergence *Divergence
+	assert.True(t, errors.As(r.Verify(), &divergence))
+	assert.ErrorIs(t, divergence, ErrDiverged)
+	assert.Equal(t, KindStep, divergence.Expected.Kind)
+	assert.Equal(t, KindStep, divergence.Actual.Kind)
+	assert.Equal(t, `"a"`, string(divergence.Expected.Args[0]))
+	assert.Equal(t, `"b"`, string(divergence.Actual.Args[0]))
+	assert.Contains(t, divergence.Error(), `["b"]`)
+
+	// the first divergence is kept, later calls are rejected
+	assert.Equal(t, world.OutcomeRejected, replayed.outcomes[1].Status)
+	assert.Equal(t, world.OutcomeRejected, replayed.outcomes[2].Status)
+	assert.Same(t, divergence, r.Err())
+}
+
+func TestReplayNoOutcome(t *testing.T) {
+	events, _ := recordEpisode(t)
+	for _, event := range events {
+		event.Outcome = nil
+	}
+
+	r := NewReplay(events)
+	replayed := runAgent(t, r, 3, typeA)
+	assert.NoError(t, r.Verify())
+	assert.Len(t, replayed.outcomes, 3)
+	for _, outcome := range replayed.outcomes {
+		assert.Equal(t, world.Rejected(ErrNoOutcome.Error()), outcome)
+	}
+}
+
+func TestReplayIncomplete(t *testing.T) {
+	events, _ := recordEpisode(t)
+	r := NewReplay(events)
+	actorId, _, err := r.NewActor()
+	assert.NoError(t, err)
+	assert.NoError(t, r.Err())
+
+	var divergence *Divergence
+	assert.True(t, errors.As(r.Verify(), &divergence))
+	assert.Nil(t, divergence.Actual)
+	assert.Equal(t, KindRegister, divergence.Expected.Kind)
+
+	// calls past the end of the trace diverge as well
+	r = NewReplay(events[:1])
+	r.NewActor()
+	r.Look(actorId)
+	assert.True(t, errors.As(r.Err(), &divergence))
+	assert.Nil(t, divergence.Expected)
+	assert.Equal(t, KindLook, divergence.Actual.Kind)
+}
+
+func TestReplayLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "episode.jsonl")
+	r, err := Create(textWorld(), path)
+	assert.NoError(t, err)
+	runAgent(t, r, 2, typeA)
+	assert.NoError(t, r.Close())
+
+	events, err := Load(path)
+	assert.NoError(t, err)
+	replay := NewReplay(events)
+	runAgent(t, replay, 2, typeA)
+	assert.NoError(t, replay.Verify())
+
+	_, err = Read(strings.NewReader("{not json}\n"))
+	assert.Error(t, err)
+}
+
+func TestReplaySnapshot(t *testing.T) {
+	play := func(w world.SafeWorld) {
+		actorId, actions, _ := w.NewActor()
+		snapshot, err := w.Snapshot()
+		assert.NoError(t, err)
+		findAction(actions, "text.typeChar").StepWith("a")
+		w.Tick()
+		assert.NoError(t, w.Restore(snapshot))
+		assert.Zero(t, w.Clock())
+		listed, _ := w.Actions(actorId)
+		assert.Equal(t, actions, listed)
+		w.Feel(actorId)
+	}
+
+	out := &bytes.Buffer{}
+	r := Record(textWorld(), out)
+	play(r)
+	assert.ErrorIs(t, r.Restore(nil), world.ErrInvalidArgs)
+	events, err := Read(out)
+	assert.NoError(t, err)
+	var kinds []string
+	for _, event := range events {
+		kinds = append(kinds, event.Kind)
+	}
+
+	assert.Equal(t, []string{KindNewActor, KindSnapshot, KindStep, KindTick, KindRestore, KindFeel, KindRestore}, kinds)
+	assert.Zero(t, events[4].Tick)
+	assert.NotEmpty(t, events[6].Error)
+
+	replay := NewReplay(events[:6])
+	play(replay)
+	assert.NoError(t, replay.Verify())
+}
@@ -0,0 +1,11 @@
+package world
+
+// Unwrap returns the world wrapped by w, i.e. by Stream, Debug or Attach, or nil if w does not wrap another world
+// wrappers follow the convention of an Unwrap() SafeWorld method
+func Unwrap(w SafeWorld) SafeWorld {
+	if wrapper, ok := w.(interface{ Unwrap() SafeWorld }); ok {
+		return wrapper.Unwrap()
+	}
+
+	return nil
+}
@@ -0,0 +1,262 @@
+package world
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// value types of LabelSpec.ValueType
+const (
+	ValueNone   = ""       // Info.Value must be nil
+	ValueInt    = "int"    // i.e. a distance in steps
+	ValueString = "string" // i.e. the reason of an outcome
+	ValueAny    = "any"    // not checked
+)
+
+// Ternary lists the members of a ternary label family, i.e. a direction relative to a cursor
+var Ternary = []string{TernaryPos, TernaryZro, TernaryNeg}
+
+/*
+LabelSpec
+
+	# declares one family of Info labels, emitted as [InfoLabelObservable, Root] or [InfoLabelObservable, Root, member]
+
+	# fields:
+		# Root: the label identifying the family, i.e. "[itemDirection]"
+		# Members: the labels allowed after Root, none if Root is the last label
+		# OpenMembers: any single label may follow Root, i.e. the shape of a character, Members then lists the known ones
+		# ValueType: one of the Value* constants, the type of Info.Value
+		# Description: meaning of the family, exported with the ontology
+*/
+type LabelSpec struct {
+	Root        string   `json:"root"`
+	Members     []string `json:"members,omitempty"`
+	OpenMembers bool     `json:"openMembers,omitempty"`
+	ValueType   string   `json:"valueType,omitempty"`
+	Description string   `json:"description"`
+}
+
+/*
+Vocabulary
+
+	# the label families a world emits in its images and touches, in declaration order
+	# declaring the same root twice fails, which catches families accidentally sharing a label
+*/
+type Vocabulary struct {
+	World string       `json:"world"`
+	Specs []*LabelSpec `json:"specs"`
+	roots map[string]*LabelSpec
+}
+
+// NewVocabulary returns a vocabulary of the given world, already declaring the labels shared by all worlds
+func NewVocabulary(world string) *Vocabulary {
+	result := &Vocabulary{World: world, roots: map[string]*LabelSpec{}}
+	for _, spec := range coreLabels {
+		_ = result.Declare(spec)
+	}
+
+	return result
+}
+
+var coreLabels = []*LabelSpec{
+	{
+		Root:        InfoLabelOutcome,
+		Members:     []string{OutcomeSuccess.Label(), OutcomeNoop.Label(), OutcomeRejected.Label(), OutcomePending.Label(), OutcomeQueued.Label()},
+		ValueType:   ValueString,
+		Description: "outcome of an action performed by the actor, the value is the reason",
+	},
+	{
+		Root:        InfoLabelClock,
+		ValueType:   ValueInt,
+		Description: "the world clock, felt whenever time passed",
+	},
+}
+
+func (v *Vocabulary) Declare(spec *LabelSpec) error {
+	if _, seen := v.roots[spec.Root]; seen {
+		return fmt.Errorf("%w: %s", ErrLabelExists, spec.Root)
+	}
+
+	v.roots[spec.Root] = spec
+	v.Specs = append(v.Specs, spec)
+	return nil
+}
+
+// Merge declares every family of other, families declared identically by both are kept once
+// conflicting families keep their existing declaration, the first conflict is returned
+func (v *Vocabulary) Merge(other *Vocabulary) error {
+	var result error
+	for _, spec := range other.Specs {
+		if existing, seen := v.roots[spec.Root]; seen && reflect.DeepEqual(existing, spec) {
+			continue
+		}
+
+		if err := v.Declare(spec); err != nil && result == nil {
+			result = err
+		}
+	}
+
+	return result
+}
+
+func (v *Vocabulary) Validate(info *Info) error {
+	if info == nil || len(info.Labels) < 2 || info.Labels[0] != InfoLabelObservable {
+		return fmt.Errorf("%w: %v", ErrInvalidLabel, info)
+	}
+
+	spec, seen := v.roots[info.Labels[1]]
+	if !seen {
+		return fmt.Errorf("%w: %s", ErrUnknownLabel, info.Labels[1])
+	}
+
+	if err := spec.validateMembers(info.Labels[2:]); err != nil {
+		return err
+	}
+
+	return spec.validateValue(info.Value)
+}
+
+func (s *LabelSpec) validateMembers(members []string) error {
+	if len(s.Members) == 0 && !s.OpenMembers {
+		if len(members) != 0 {
+			return fmt.Errorf("%w: %s takes no member, got %v", ErrInvalidLabel, s.Root, members)
+		}
+
+		return nil
+	}
+
+	if len(members) != 1 {
+		return fmt.Errorf("%w: %s takes one member, got %v", ErrInvalidLabel, s.Root, members)
+	}
+
+	if s.OpenMembers {
+		return nil
+	}
+
+	for _, member := range s.Members {
+		if member == members[0] {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %s is not a member of %s", ErrInvalidLabel, members[0], s.Root)
+}
+
+func (s *LabelSpec) validateValue(value any) error {
+	valid := true
+	switch s.ValueType {
+	case ValueNone:
+		valid = value == nil
+	case ValueInt:
+		_, valid = value.(int)
+	case ValueString:
+		_, valid = value.(string)
+	}
+
+	if !valid {
+		return fmt.Errorf("%w: %s expects %q, got %T", ErrInvalidValue, s.Root, s.ValueType, value)
+	}
+
+	return nil
+}
+
+func (v *Vocabulary) ValidateImage(img *Image) error {
+	for _, info := range img.Permanent {
+		if err := v.Validate(info); err != nil {
+			return fmt.Errorf("image %d: %w", img.Id, err)
+		}
+	}
+
+	for _, info := range img.Transient {
+		if err := v.Validate(info); err != nil {
+			return fmt.Errorf("image %d: %w", img.Id, err)
+		}
+	}
+
+	return nil
+}
+
+func (v *Vocabulary) ValidateTouch(touch *Touch) error {
+	if err := v.Validate(touch.Info); err != nil {
+		return fmt.Errorf("touch %s: %w", touch.Name, err)
+	}
+
+	return nil
+}
+
+// VocabularyProvider is implemented by worlds declaring the labels they emit
+type VocabularyProvider interface {
+	Vocabulary() *Vocabulary
+}
+
+var (
+	vocabulariesMu sync.Mutex
+	vocabularies   = map[string]*Vocabulary{}
+)
+
+// RegisterVocabulary adds a world's vocabulary to the ontology, replacing any previous one of the same world
+func RegisterVocabulary(v *Vocabulary) {
+	vocabulariesMu.Lock()
+	defer vocabulariesMu.Unlock()
+	vocabularies[v.World] = v
+}
+
+// Ontology exports every registered vocabulary ordered by world name, ready to be encoded as JSON
+func Ontology() []*Vocabulary {
+	vocabulariesMu.Lock()
+	defer vocabulariesMu.Unlock()
+	var result []*Vocabulary
+	for _, v := range vocabularies {
+		result = append(result, v)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].World < result[j].World
+	})
+
+	return result
+}
+
+/*
+Debug
+
+	# wraps a world in debug mode, validating every image and touch it emits against v
+	# violations are passed to report, the observations themselves are returned unchanged
+*/
+func Debug(w SafeWorld, v *Vocabulary, report func(err error)) SafeWorld {
+	return &debugWorld{SafeWorld: w, v: v, report: report}
+}
+
+type debugWorld struct {
+	SafeWorld
+	v      *Vocabulary
+	report func(err error)
+}
+
+func (w *debugWorld) Unwrap() SafeWorld {
+	return w.SafeWorld
+}
+
+func (w *debugWorld) Look(actorId int) []*Image {
+	result := w.SafeWorld.Look(actorId)
+	for _, img := range result {
+		if err := w.v.ValidateImage(img); err != nil {
+			w.report(err)
+		}
+	}
+
+	return result
+}
+
+func (w *debugWorld) Feel(actorId int) []*Touch {
+	result := w.SafeWorld.Feel(actorId)
+	for _, touch := range result {
+		if err := w.v.ValidateTouch(touch); err != nil {
+			w.report(err)
+		}
+	}
+
+	return result
+}
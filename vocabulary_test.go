@@ -0,0 +1,79 @@
+package world
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testVocabulary() *Vocabulary {
+	v := NewVocabulary("test")
+	_ = v.Declare(&LabelSpec{Root: "[direction]", Members: Ternary, ValueType: ValueInt})
+	_ = v.Declare(&LabelSpec{Root: "[shape]", OpenMembers: true})
+	return v
+}
+
+func TestVocabularyDeclare(t *testing.T) {
+	v := testVocabulary()
+	assert.ErrorIs(t, v.Declare(&LabelSpec{Root: "[direction]"}), ErrLabelExists)
+	assert.ErrorIs(t, v.Declare(&LabelSpec{Root: InfoLabelClock}), ErrLabelExists)
+
+	other := NewVocabulary("other")
+	_ = other.Declare(&LabelSpec{Root: "[direction]", Members: Ternary, ValueType: ValueInt})
+	_ = other.Declare(&LabelSpec{Root: "[size]", ValueType: ValueInt})
+	assert.NoError(t, v.Merge(other))
+	assert.NoError(t, v.Validate(&Info{Labels: []string{InfoLabelObservable, "[size]"}, Value: 1}))
+
+	conflicting := NewVocabulary("conflicting")
+	_ = conflicting.Declare(&LabelSpec{Root: "[shape]"})
+	assert.ErrorIs(t, v.Merge(conflicting), ErrLabelExists)
+}
+
+func TestVocabularyValidate(t *testing.T) {
+	v := testVocabulary()
+	assert.NoError(t, v.Validate(&Info{Labels: []string{InfoLabelObservable, "[direction]", TernaryPos}, Value: 2}))
+	assert.NoError(t, v.Validate(&Info{Labels: []string{InfoLabelObservable, "[shape]", "a"}}))
+	assert.NoError(t, v.ValidateTouch(ClockTouch(1, 3)))
+	assert.NoError(t, v.ValidateTouch(Rejected("reason").Touch(1, "action")))
+
+	assert.ErrorIs(t, v.Validate(nil), ErrInvalidLabel)
+	assert.ErrorIs(t, v.Validate(&Info{Labels: []string{"[direction]", TernaryPos}}), ErrInvalidLabel)
+	assert.ErrorIs(t, v.Validate(&Info{Labels: []string{InfoLabelObservable, "[lineDirection]", TernaryPos}}), ErrUnknownLabel)
+	assert.ErrorIs(t, v.Validate(&Info{Labels: []string{InfoLabelObservable, "[direction]", "[up]"}, Value: 2}), ErrInvalidLabel)
+	assert.ErrorIs(t, v.Validate(&Info{Labels: []string{InfoLabelObservable, "[direction]"}, Value: 2}), ErrInvalidLabel)
+	assert.ErrorIs(t, v.Validate(&Info{Labels: []string{InfoLabelObservable, InfoLabelClock, "a"}, Value: 2}), ErrInvalidLabel)
+	assert.ErrorIs(t, v.Validate(&Info{Labels: []string{InfoLabelObservable, "[direction]", TernaryPos}, Value: "2"}), ErrInvalidValue)
+	assert.ErrorIs(t, v.Validate(&Info{Labels: []string{InfoLabelObservable, "[shape]", "a"}, Value: 1}), ErrInvalidValue)
+
+	img := &Image{Id: 7, Transient: []*Info{{Labels: []string{InfoLabelObservable, "[unknown]"}}}}
+	assert.ErrorIs(t, v.ValidateImage(img), ErrUnknownLabel)
+}
+
+func TestDebug(t *testing.T) {
+	lw := &lookWorld{images: []*Image{
+		{Id: 1, Permanent: []*Info{{Labels: []string{InfoLabelObservable, "[shape]", "a"}}}},
+		{Id: 2, Transient: []*Info{{Labels: []string{InfoLabelObservable, "[direction]", TernaryPos}}}},
+	}}
+
+	var reported []error
+	w := Debug(Recover(lw), testVocabulary(), func(err error) {
+		reported = append(reported, err)
+	})
+
+	assert.Len(t, w.Look(1), 2)
+	assert.Len(t, reported, 1)
+	assert.ErrorIs(t, reported[0], ErrInvalidValue)
+	assert.Empty(t, w.Feel(1))
+}
+
+func TestOntology(t *testing.T) {
+	v := testVocabulary()
+	RegisterVocabulary(v)
+	assert.Contains(t, Ontology(), v)
+
+	encoded, err := json.Marshal(v)
+	assert.NoError(t, err)
+	assert.Contains(t, string(encoded), `"root":"[direction]"`)
+	assert.Contains(t, string(encoded), `"openMembers":true`)
+}
@@ -0,0 +1,343 @@
+package wire
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	world "github.com/sapphire-ai-dev/sapphire-world"
+)
+
+// magic prefixes every binary message, followed by the version byte
+var magic = []byte("SW")
+
+type encoder struct {
+	buf []byte
+}
+
+func (e *encoder) uvarint(v uint64) {
+	e.buf = binary.AppendUvarint(e.buf, v)
+}
+
+func (e *encoder) varint(v int64) {
+	e.buf = binary.AppendVarint(e.buf, v)
+}
+
+func (e *encoder) bool(v bool) {
+	if v {
+		e.buf = append(e.buf, 1)
+	} else {
+		e.buf = append(e.buf, 0)
+	}
+}
+
+func (e *encoder) string(v string) {
+	e.uvarint(uint64(len(v)))
+	e.buf = append(e.buf, v...)
+}
+
+func (e *encoder) strings(v []string) {
+	e.uvarint(uint64(len(v)))
+	for _, s := range v {
+		e.string(s)
+	}
+}
+
+func (e *encoder) info(info *world.Info) error {
+	if info == nil {
+		return fmt.Errorf("%w: nil info", world.ErrInvalidArgs)
+	}
+
+	kind, err := kindOf(info.Value)
+	if err != nil {
+		return err
+	}
+
+	e.strings(info.Labels)
+	e.buf = append(e.buf, kind)
+	switch kind {
+	case kindBool:
+		e.bool(info.Value.(bool))
+	case kindInt:
+		e.varint(intOf(info.Value))
+	case kindFloat:
+		e.buf = binary.LittleEndian.AppendUint64(e.buf, math.Float64bits(info.Value.(float64)))
+	case kindString:
+		e.string(info.Value.(string))
+	}
+
+	return nil
+}
+
+func (e *encoder) infos(infos []*world.Info) error {
+	e.uvarint(uint64(len(infos)))
+	for _, info := range infos {
+		if err := e.info(info); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func MarshalBinary(m *Message) ([]byte, error) {
+	e := &encoder{buf: append(append([]byte{}, magic...), Version)}
+	e.uvarint(uint64(len(m.Images)))
+	for _, img := range m.Images {
+		if img == nil {
+			return nil, fmt.Errorf("%w: nil image", world.ErrInvalidArgs)
+		}
+
+		e.varint(int64(img.Id))
+		e.string(img.Name)
+		e.varint(int64(img.Time))
+		if err := e.infos(img.Permanent); err != nil {
+			return nil, err
+		}
+
+		if err := e.infos(img.Transient); err != nil {
+			return nil, err
+		}
+	}
+
+	e.uvarint(uint64(len(m.Touches)))
+	for _, touch := range m.Touches {
+		if touch == nil {
+			return nil, fmt.Errorf("%w: nil touch", world.ErrInvalidArgs)
+		}
+
+		e.varint(int64(touch.Id))
+		e.string(touch.Name)
+		e.varint(int64(touch.Time))
+		e.bool(touch.Info != nil)
+		if touch.Info != nil {
+			if err := e.info(touch.Info); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	e.uvarint(uint64(len(m.Actions)))
+	for _, action := range m.Actions {
+		if action == nil {
+			return nil, fmt.Errorf("%w: nil action", world.ErrInvalidArgs)
+		}
+
+		e.string(action.Name)
+		e.string(action.Id)
+		e.string(action.Category)
+		e.string(action.Description)
+		e.string(action.World)
+		e.bool(action.Parameterized)
+		e.varint(int64(action.Duration))
+		e.varint(int64(action.Cooldown))
+		e.bool(action.Shorthand)
+		e.uvarint(uint64(len(action.Schema)))
+		for _, param := range action.Schema {
+			if err := checkParam(param); err != nil {
+				return nil, err
+			}
+
+			e.string(param.Name)
+			e.varint(int64(param.Kind))
+			e.varint(int64(param.Min))
+			e.varint(int64(param.Max))
+			e.strings(param.Values)
+			e.varint(int64(param.MaxLen))
+		}
+	}
+
+	return e.buf, nil
+}
+
+// decoder records the first failure and returns zero values from then on
+type decoder struct {
+	buf []byte
+	err error
+}
+
+func (d *decoder) fail() {
+	if d.err == nil {
+		d.err = ErrCorrupt
+	}
+}
+
+func (d *decoder) uvarint() uint64 {
+	v, n := binary.Uvarint(d.buf)
+	if n <= 0 {
+		d.fail()
+		return 0
+	}
+
+	d.buf = d.buf[n:]
+	return v
+}
+
+func (d *decoder) varint() int64 {
+	v, n := binary.Varint(d.buf)
+	if n <= 0 {
+		d.fail()
+		return 0
+	}
+
+	d.buf = d.buf[n:]
+	return v
+}
+
+func (d *decoder) int() int {
+	return int(d.varint())
+}
+
+func (d *decoder) byte() byte {
+	if len(d.buf) < 1 {
+		d.fail()
+		return 0
+	}
+
+	v := d.buf[0]
+	d.buf = d.buf[1:]
+	return v
+}
+
+func (d *decoder) bool() bool {
+	return d.byte() == 1
+}
+
+// count reads a collection length, every element takes at least one byte
+func (d *decoder) count() int {
+	v := d.uvarint()
+	if v > uint64(len(d.buf)) {
+		d.fail()
+		return 0
+	}
+
+	return int(v)
+}
+
+func (d *decoder) string() string {
+	n := d.count()
+	if d.err != nil {
+		return ""
+	}
+
+	v := string(d.buf[:n])
+	d.buf = d.buf[n:]
+	return v
+}
+
+func (d *decoder) strings() []string {
+	var result []string
+	for i, n := 0, d.count(); i < n && d.err == nil; i++ {
+		result = append(result, d.string())
+	}
+
+	return result
+}
+
+func (d *decoder) info() *world.Info {
+	result := &world.Info{Labels: d.strings()}
+	switch d.byte() {
+	case kindNil:
+	case kindBool:
+		result.Value = d.bool()
+	case kindInt:
+		result.Value = d.int()
+	case kindFloat:
+		if len(d.buf) < 8 {
+			d.fail()
+			return result
+		}
+
+		result.Value = math.Float64frombits(binary.LittleEndian.Uint64(d.buf))
+		d.buf = d.buf[8:]
+	case kindString:
+		result.Value = d.string()
+	default:
+		d.fail()
+	}
+
+	return result
+}
+
+func (d *decoder) infos() []*world.Info {
+	var result []*world.Info
+	for i, n := 0, d.count(); i < n && d.err == nil; i++ {
+		result = append(result, d.info())
+	}
+
+	return result
+}
+
+func UnmarshalBinary(data []byte) (*Message, error) {
+	if len(data) < len(magic)+1 || string(data[:len(magic)]) != string(magic) {
+		return nil, ErrCorrupt
+	}
+
+	if version := data[len(magic)]; version != Version {
+		return nil, fmt.Errorf("%w: %d", ErrVersion, version)
+	}
+
+	d := &decoder{buf: data[len(magic)+1:]}
+	result := &Message{}
+	for i, n := 0, d.count(); i < n && d.err == nil; i++ {
+		result.Images = append(result.Images, &world.Image{
+			Id:        d.int(),
+			Name:      d.string(),
+			Time:      d.int(),
+			Permanent: d.infos(),
+			Transient: d.infos(),
+		})
+	}
+
+	for i, n := 0, d.count(); i < n && d.err == nil; i++ {
+		touch := &world.Touch{Id: d.int(), Name: d.string(), Time: d.int()}
+		if d.bool() {
+			touch.Info = d.info()
+		}
+
+		result.Touches = append(result.Touches, touch)
+	}
+
+	for i, n := 0, d.count(); i < n && d.err == nil; i++ {
+		action := &world.ActionDescription{
+			Name:          d.string(),
+			Id:            d.string(),
+			Category:      d.string(),
+			Description:   d.string(),
+			World:         d.string(),
+			Parameterized: d.bool(),
+			Duration:      d.int(),
+			Cooldown:      d.int(),
+			Shorthand:     d.bool(),
+		}
+
+		for j, m := 0, d.count(); j < m && d.err == nil; j++ {
+			param := &world.Param{
+				Name:   d.string(),
+				Kind:   world.ParamKind(d.varint()),
+				Min:    d.int(),
+				Max:    d.int(),
+				Values: d.strings(),
+				MaxLen: d.int(),
+			}
+
+			if _, known := paramKindNames[param.Kind]; !known {
+				d.fail()
+			}
+
+			action.Schema = append(action.Schema, param)
+		}
+
+		result.Actions = append(result.Actions, action)
+	}
+
+	if d.err == nil && len(d.buf) != 0 {
+		d.fail()
+	}
+
+	if d.err != nil {
+		return nil, d.err
+	}
+
+	return result, nil
+}
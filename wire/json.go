@@ -0,0 +1,296 @@
+package wire
+
+import (
+	"encoding/json"
+	"fmt"
+
+	world "github.com/sapphire-ai-dev/sapphire-world"
+)
+
+type jsonMessage struct {
+	Version int           `json:"version"`
+	Images  []*jsonImage  `json:"images,omitempty"`
+	Touches []*jsonTouch  `json:"touches,omitempty"`
+	Actions []*jsonAction `json:"actions,omitempty"`
+}
+
+type jsonImage struct {
+	Id        int         `json:"id"`
+	Name      string      `json:"name,omitempty"`
+	Permanent []*jsonInfo `json:"permanent,omitempty"`
+	Transient []*jsonInfo `json:"transient,omitempty"`
+	Time      int         `json:"time,omitempty"`
+}
+
+type jsonTouch struct {
+	Id   int       `json:"id"`
+	Name string    `json:"name,omitempty"`
+	Info *jsonInfo `json:"info,omitempty"`
+	Time int       `json:"time,omitempty"`
+}
+
+// jsonInfo keeps the type of the value explicit, at most one of the value fields is set
+type jsonInfo struct {
+	Labels []string `json:"labels"`
+	Bool   *bool    `json:"bool,omitempty"`
+	Int    *int64   `json:"int,omitempty"`
+	Float  *float64 `json:"float,omitempty"`
+	String *string  `json:"string,omitempty"`
+}
+
+type jsonAction struct {
+	Name          string       `json:"name"`
+	Id            string       `json:"id"`
+	Category      string       `json:"category,omitempty"`
+	Description   string       `json:"description,omitempty"`
+	World         string       `json:"world,omitempty"`
+	Schema        []*jsonParam `json:"schema,omitempty"`
+	Parameterized bool         `json:"parameterized,omitempty"`
+	Duration      int          `json:"duration,omitempty"`
+	Cooldown      int          `json:"cooldown,omitempty"`
+	Shorthand     bool         `json:"shorthand,omitempty"`
+}
+
+type jsonParam struct {
+	Name   string   `json:"name"`
+	Kind   string   `json:"kind"`
+	Min    int      `json:"min,omitempty"`
+	Max    int      `json:"max,omitempty"`
+	Values []string `json:"values,omitempty"`
+	MaxLen int      `json:"maxLen,omitempty"`
+}
+
+func MarshalJSON(m *Message) ([]byte, error) {
+	result := &jsonMessage{Version: Version}
+	for _, img := range m.Images {
+		if img == nil {
+			return nil, fmt.Errorf("%w: nil image", world.ErrInvalidArgs)
+		}
+
+		encoded, err := toJSONImage(img)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Images = append(result.Images, encoded)
+	}
+
+	for _, touch := range m.Touches {
+		if touch == nil {
+			return nil, fmt.Errorf("%w: nil touch", world.ErrInvalidArgs)
+		}
+
+		encoded := &jsonTouch{Id: touch.Id, Name: touch.Name, Time: touch.Time}
+		if touch.Info != nil {
+			info, err := toJSONInfo(touch.Info)
+			if err != nil {
+				return nil, err
+			}
+
+			encoded.Info = info
+		}
+
+		result.Touches = append(result.Touches, encoded)
+	}
+
+	for _, action := range m.Actions {
+		encoded, err := toJSONAction(action)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Actions = append(result.Actions, encoded)
+	}
+
+	return json.Marshal(result)
+}
+
+func UnmarshalJSON(data []byte) (*Message, error) {
+	var decoded jsonMessage
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCorrupt, err)
+	}
+
+	if decoded.Version != Version {
+		return nil, fmt.Errorf("%w: %d", ErrVersion, decoded.Version)
+	}
+
+	result := &Message{}
+	for _, img := range decoded.Images {
+		result.Images = append(result.Images, &world.Image{
+			Id:        img.Id,
+			Name:      img.Name,
+			Permanent: fromJSONInfos(img.Permanent),
+			Transient: fromJSONInfos(img.Transient),
+			Time:      img.Time,
+		})
+	}
+
+	for _, touch := range decoded.Touches {
+		decodedTouch := &world.Touch{Id: touch.Id, Name: touch.Name, Time: touch.Time}
+		if touch.Info != nil {
+			decodedTouch.Info = fromJSONInfo(touch.Info)
+		}
+
+		result.Touches = append(result.Touches, decodedTouch)
+	}
+
+	for _, action := range decoded.Actions {
+		decodedAction, err := fromJSONAction(action)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Actions = append(result.Actions, decodedAction)
+	}
+
+	return result, nil
+}
+
+func toJSONImage(img *world.Image) (*jsonImage, error) {
+	result := &jsonImage{Id: img.Id, Name: img.Name, Time: img.Time}
+	for _, info := range img.Permanent {
+		encoded, err := toJSONInfo(info)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Permanent = append(result.Permanent, encoded)
+	}
+
+	for _, info := range img.Transient {
+		encoded, err := toJSONInfo(info)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Transient = append(result.Transient, encoded)
+	}
+
+	return result, nil
+}
+
+func toJSONInfo(info *world.Info) (*jsonInfo, error) {
+	if info == nil {
+		return nil, fmt.Errorf("%w: nil info", world.ErrInvalidArgs)
+	}
+
+	kind, err := kindOf(info.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &jsonInfo{Labels: info.Labels}
+	switch kind {
+	case kindBool:
+		v := info.Value.(bool)
+		result.Bool = &v
+	case kindInt:
+		v := intOf(info.Value)
+		result.Int = &v
+	case kindFloat:
+		v := info.Value.(float64)
+		result.Float = &v
+	case kindString:
+		v := info.Value.(string)
+		result.String = &v
+	}
+
+	return result, nil
+}
+
+func fromJSONInfos(infos []*jsonInfo) []*world.Info {
+	var result []*world.Info
+	for _, info := range infos {
+		result = append(result, fromJSONInfo(info))
+	}
+
+	return result
+}
+
+func fromJSONInfo(info *jsonInfo) *world.Info {
+	result := &world.Info{}
+	if len(info.Labels) > 0 {
+		result.Labels = info.Labels
+	}
+
+	switch {
+	case info.Bool != nil:
+		result.Value = *info.Bool
+	case info.Int != nil:
+		result.Value = int(*info.Int)
+	case info.Float != nil:
+		result.Value = *info.Float
+	case info.String != nil:
+		result.Value = *info.String
+	}
+
+	return result
+}
+
+func toJSONAction(action *world.ActionDescription) (*jsonAction, error) {
+	if action == nil {
+		return nil, fmt.Errorf("%w: nil action", world.ErrInvalidArgs)
+	}
+
+	result := &jsonAction{
+		Name:          action.Name,
+		Id:            action.Id,
+		Category:      action.Category,
+		Description:   action.Description,
+		World:         action.World,
+		Parameterized: action.Parameterized,
+		Duration:      action.Duration,
+		Cooldown:      action.Cooldown,
+		Shorthand:     action.Shorthand,
+	}
+
+	for _, param := range action.Schema {
+		if err := checkParam(param); err != nil {
+			return nil, err
+		}
+
+		result.Schema = append(result.Schema, &jsonParam{
+			Name:   param.Name,
+			Kind:   paramKindNames[param.Kind],
+			Min:    param.Min,
+			Max:    param.Max,
+			Values: param.Values,
+			MaxLen: param.MaxLen,
+		})
+	}
+
+	return result, nil
+}
+
+func fromJSONAction(action *jsonAction) (*world.ActionDescription, error) {
+	result := &world.ActionDescription{
+		Name:          action.Name,
+		Id:            action.Id,
+		Category:      action.Category,
+		Description:   action.Description,
+		World:         action.World,
+		Parameterized: action.Parameterized,
+		Duration:      action.Duration,
+		Cooldown:      action.Cooldown,
+		Shorthand:     action.Shorthand,
+	}
+
+	for _, param := range action.Schema {
+		kind, err := paramKindOf(param.Kind)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Schema = append(result.Schema, &world.Param{
+			Name:   param.Name,
+			Kind:   kind,
+			Min:    param.Min,
+			Max:    param.Max,
+			Values: param.Values,
+			MaxLen: param.MaxLen,
+		})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,100 @@
+// Package wire encodes observations and action descriptions for shipping them between processes or storing them.
+// Both encodings are versioned and deterministic: equal messages always encode to equal bytes.
+package wire
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	world "github.com/sapphire-ai-dev/sapphire-world"
+)
+
+// Version of the encoding written by this package, decoding rejects any other version
+const Version = 2
+
+var (
+	ErrVersion          = errors.New("unsupported encoding version")
+	ErrUnsupportedValue = errors.New("info value type cannot be encoded")
+	ErrCorrupt          = errors.New("malformed encoding")
+)
+
+/*
+Message
+
+	# the unit of encoding, any of its collections may be empty
+	# Info.Value may hold nil, bool, int, float64 or string, int64 values decode as int, NaN and infinities are rejected
+	# empty slices decode as nil
+*/
+type Message struct {
+	Images  []*world.Image
+	Touches []*world.Touch
+	Actions []*world.ActionDescription
+}
+
+// value kinds, shared by both encodings
+const (
+	kindNil byte = iota
+	kindBool
+	kindInt
+	kindFloat
+	kindString
+)
+
+func kindOf(value any) (byte, error) {
+	switch v := value.(type) {
+	case nil:
+		return kindNil, nil
+	case bool:
+		return kindBool, nil
+	case int, int64:
+		return kindInt, nil
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return 0, fmt.Errorf("%w: %v", ErrUnsupportedValue, v)
+		}
+
+		return kindFloat, nil
+	case string:
+		return kindString, nil
+	}
+
+	return 0, fmt.Errorf("%w: %T", ErrUnsupportedValue, value)
+}
+
+func intOf(value any) int64 {
+	if v, ok := value.(int); ok {
+		return int64(v)
+	}
+
+	return value.(int64)
+}
+
+var paramKindNames = map[world.ParamKind]string{
+	world.ParamInt:    "int",
+	world.ParamEnum:   "enum",
+	world.ParamString: "string",
+}
+
+// checkParam rejects params that cannot be encoded, decoders reject unknown kinds as corrupt
+func checkParam(param *world.Param) error {
+	if param == nil {
+		return fmt.Errorf("%w: nil param", world.ErrInvalidArgs)
+	}
+
+	if _, known := paramKindNames[param.Kind]; !known {
+		return fmt.Errorf("%w: param kind %d", world.ErrInvalidArgs, param.Kind)
+	}
+
+	return nil
+}
+
+func paramKindOf(name string) (world.ParamKind, error) {
+	for kind, kindName := range paramKindNames {
+		if kindName == name {
+			return kind, nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w: param kind %q", ErrCorrupt, name)
+}
@@ -0,0 +1,142 @@
+package wire
+
+import (
+	"math"
+	"testing"
+
+	world "github.com/sapphire-ai-dev/sapphire-world"
+	"github.com/stretchr/testify/assert"
+)
+
+func testMessage() *Message {
+	return &Message{
+		Images: []*world.Image{
+			{
+				Id:   1,
+				Name: "fName",
+				Permanent: []*world.Info{
+					{Labels: []string{world.InfoLabelObservable, "[itemType]", "[file]"}},
+				},
+				Transient: []*world.Info{
+					{Labels: []string{world.InfoLabelObservable, "[itemDirection]", world.TernaryNeg}, Value: -3},
+					{Labels: []string{"float"}, Value: 0.1},
+					{Labels: []string{"bool"}, Value: true},
+				},
+				Time: 7,
+			},
+			{Id: -2},
+		},
+		Touches: []*world.Touch{
+			world.Rejected("reason").Touch(1, "keya"),
+			world.ClockTouch(1, 7),
+			{Id: 3, Name: "empty"},
+		},
+		Actions: []*world.ActionDescription{
+			{Name: "keya", Id: "text.pressKey.a", Category: "pressKey", World: "text", Duration: 2},
+			{
+				Name:          "typeChar",
+				Id:            "text.typeChar",
+				Parameterized: true,
+				Shorthand:     true,
+				Schema: world.Schema{
+					world.EnumParam("char", "a", "b"),
+					world.IntParam("count", -1, 5),
+					world.StringParam("text", 10),
+				},
+			},
+		},
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	m := testMessage()
+	for _, codec := range []struct {
+		marshal   func(*Message) ([]byte, error)
+		unmarshal func([]byte) (*Message, error)
+	}{
+		{MarshalJSON, UnmarshalJSON},
+		{MarshalBinary, UnmarshalBinary},
+	} {
+		encoded, err := codec.marshal(m)
+		assert.NoError(t, err)
+		decoded, err := codec.unmarshal(encoded)
+		assert.NoError(t, err)
+		assert.Equal(t, m, decoded)
+
+		// equal messages encode to equal bytes
+		again, _ := codec.marshal(decoded)
+		assert.Equal(t, encoded, again)
+
+		empty, err := codec.marshal(&Message{})
+		assert.NoError(t, err)
+		decoded, err = codec.unmarshal(empty)
+		assert.NoError(t, err)
+		assert.Equal(t, &Message{}, decoded)
+	}
+}
+
+func TestInt64Value(t *testing.T) {
+	m := &Message{Touches: []*world.Touch{{Info: &world.Info{Value: int64(5)}}}}
+	encoded, _ := MarshalBinary(m)
+	decoded, _ := UnmarshalBinary(encoded)
+	assert.Equal(t, 5, decoded.Touches[0].Info.Value)
+}
+
+func TestUnsupportedValue(t *testing.T) {
+	for _, value := range []any{struct{}{}, []int{1}, math.NaN(), math.Inf(1)} {
+		m := &Message{Touches: []*world.Touch{{Info: &world.Info{Value: value}}}}
+		_, err := MarshalJSON(m)
+		assert.ErrorIs(t, err, ErrUnsupportedValue)
+		_, err = MarshalBinary(m)
+		assert.ErrorIs(t, err, ErrUnsupportedValue)
+	}
+}
+
+func TestInvalidMessage(t *testing.T) {
+	for _, m := range []*Message{
+		{Images: []*world.Image{nil}},
+		{Images: []*world.Image{{Permanent: []*world.Info{nil}}}},
+		{Images: []*world.Image{{Transient: []*world.Info{nil}}}},
+		{Touches: []*world.Touch{nil}},
+		{Actions: []*world.ActionDescription{nil}},
+		{Actions: []*world.ActionDescription{{Schema: world.Schema{nil}}}},
+		{Actions: []*world.ActionDescription{{Schema: world.Schema{{Name: "v", Kind: world.ParamKind(9)}}}}},
+	} {
+		_, err := MarshalJSON(m)
+		assert.ErrorIs(t, err, world.ErrInvalidArgs)
+		_, err = MarshalBinary(m)
+		assert.ErrorIs(t, err, world.ErrInvalidArgs)
+	}
+}
+
+func TestVersion(t *testing.T) {
+	_, err := UnmarshalJSON([]byte(`{"version":3}`))
+	assert.ErrorIs(t, err, ErrVersion)
+	_, err = UnmarshalBinary([]byte{'S', 'W', 3, 0, 0, 0})
+	assert.ErrorIs(t, err, ErrVersion)
+}
+
+func TestCorrupt(t *testing.T) {
+	_, err := UnmarshalJSON([]byte(`{`))
+	assert.ErrorIs(t, err, ErrCorrupt)
+	_, err = UnmarshalJSON([]byte(`{"version":2,"actions":[{"schema":[{"kind":"?"}]}]}`))
+	assert.ErrorIs(t, err, ErrCorrupt)
+
+	encoded, _ := MarshalBinary(testMessage())
+	for n := 0; n < len(encoded); n++ {
+		_, err = UnmarshalBinary(encoded[:n])
+		assert.ErrorIs(t, err, ErrCorrupt)
+	}
+
+	_, err = UnmarshalBinary(append(encoded, 0))
+	assert.ErrorIs(t, err, ErrCorrupt)
+
+	// an unknown param kind is corrupt, the kind is followed by the single byte min, max, values and maxLen
+	action := &Message{Actions: []*world.ActionDescription{{Schema: world.Schema{{Name: "v", Kind: world.ParamInt}}}}}
+	encoded, _ = MarshalBinary(action)
+	kindAt := len(encoded) - 5
+	assert.Equal(t, byte(world.ParamInt), encoded[kindAt])
+	encoded[kindAt] = 18
+	_, err = UnmarshalBinary(encoded)
+	assert.ErrorIs(t, err, ErrCorrupt)
+}
@@ -1,7 +1,5 @@
 package world
 
-var currentWorld World
-
 /*
 World
 
@@ -15,9 +13,11 @@ World
         # NewActor: creates a new actor
             # return: unit id of actor
             # return: list of atomic action interfaces provided by the world
-            # return: an action response to communicate outcome of invoking an action interface
+            # the outcome of invoking an action interface is returned by its Step and felt on the next Feel
         # Register: registers a cycle function
             # all registered cycle functions will be executed per tick
+            # SafeWorld implementations honor CycleOption, i.e. CyclePriority and CycleName
+            # SafeWorld.Register returns a CycleHandle that unregisters the cycle function when cancelled
         # Look: an actor looks, receiving a collection of images
             # id: id of the actor that looks
             # return: list of images the actor sees
@@ -33,3 +33,47 @@ type World interface {
     Feel(actorId int) []*Touch
     Cmd(args ...any)
 }
+
+/*
+SafeWorld
+
+    # error-returning variant of World
+    # a bad call made by an agent is reported back to the caller instead of panicking
+
+    # methods:
+        # same as World, except:
+        # NewActor: additionally returns ErrInvalidArgs if the arguments are not understood by the world
+        # Register: returns ErrActorNotFound if the actor does not exist
+        # Cmd: returns ErrInvalidArgs / ErrWorldNotFound / implementation-specific errors
+    # additional methods:
+        # RemoveActor: eliminates an actor together with all its cycle functions
+            # returns ErrActorNotFound if the actor does not exist
+        # Lifecycle: the world's actor lifecycle events, emitted on NewActor and RemoveActor
+        # Actions: lists all action interfaces an actor has, as returned by NewActor
+            # returns ErrActorNotFound if the actor does not exist
+        # Clock: number of ticks since the last Reset
+            # images and touches carry the clock they were observed at in their Time field
+        # Snapshot: saves the state of the world, see Snapshot
+            # returns ErrUnsupported if the world cannot be saved
+        # Restore: brings the world back to a saved state
+            # returns ErrSnapshotMismatch if the snapshot was taken by another world
+        # Digest: canonical hash of the full state of the world, see Digest and CheckDeterminism
+            # returns ErrUnsupported if the world cannot hash its state
+*/
+type SafeWorld interface {
+	Name() string
+	Reset()
+	Tick()
+	NewActor(args ...any) (int, []*ActionInterface, error)
+	Register(actorId int, cycle func(), opts ...CycleOption) (*CycleHandle, error)
+	Look(actorId int) []*Image
+	Feel(actorId int) []*Touch
+	Cmd(args ...any) error
+	RemoveActor(actorId int) error
+	Lifecycle() *Lifecycle
+	Actions(actorId int) ([]*ActionInterface, error)
+	Clock() int
+	Snapshot() (*Snapshot, error)
+	Restore(snapshot *Snapshot) error
+	Digest() (Digest, error)
+}
@@ -1,41 +1,103 @@
 package world
 
-var lastUnitId int
-
 func NewUnitId() int {
-	lastUnitId++
-	return lastUnitId
+	return defaultSession.NewUnitId()
 }
 
+// SetWorld installs a panicking World, wrapped with Recover
 func SetWorld(w World) {
-	currentWorld = w
+	defaultSession.SetWorld(w)
+}
+
+func SetSafeWorld(w SafeWorld) {
+	defaultSession.SetSafeWorld(w)
+}
+
+func GetWorld() World {
+	return defaultSession.GetWorld()
+}
+
+func GetSafeWorld() SafeWorld {
+	return defaultSession.GetSafeWorld()
 }
 
 func Reset() {
-	lastUnitId = 1 >> 16 // start at a high number to simplify cmd+F during debugging
-	currentWorld.Reset()
+	defaultSession.Reset()
 }
 
 func Tick() {
-	currentWorld.Tick()
+	defaultSession.Tick()
+}
+
+func Clock() int {
+	return defaultSession.Clock()
+}
+
+// TakeSnapshot saves the state of the default session's world, see Snapshot
+func TakeSnapshot() (*Snapshot, error) {
+	return defaultSession.Snapshot()
+}
+
+func Restore(snapshot *Snapshot) error {
+	return defaultSession.Restore(snapshot)
 }
 
 func NewActor(args ...any) (int, []*ActionInterface) {
-	return currentWorld.NewActor(args...)
+	return defaultSession.NewActor(args...)
+}
+
+func TryNewActor(args ...any) (int, []*ActionInterface, error) {
+	return defaultSession.TryNewActor(args...)
+}
+
+func Register(id int, cycle func(), opts ...CycleOption) *CycleHandle {
+	return defaultSession.Register(id, cycle, opts...)
 }
 
-func Register(id int, cycle func()) {
-	currentWorld.Register(id, cycle)
+func TryRegister(id int, cycle func(), opts ...CycleOption) (*CycleHandle, error) {
+	return defaultSession.TryRegister(id, cycle, opts...)
+}
+
+func RemoveActor(id int) {
+	defaultSession.RemoveActor(id)
+}
+
+func TryRemoveActor(id int) error {
+	return defaultSession.TryRemoveActor(id)
+}
+
+func GetLifecycle() *Lifecycle {
+	return defaultSession.GetLifecycle()
+}
+
+func Actions(id int) []*ActionInterface {
+	return defaultSession.Actions(id)
+}
+
+func TryActions(id int) ([]*ActionInterface, error) {
+	return defaultSession.TryActions(id)
 }
 
 func Look(id int) []*Image {
-	return currentWorld.Look(id)
+	return defaultSession.Look(id)
+}
+
+func Subscribe(id int) (*Subscription, error) {
+	return defaultSession.Subscribe(id)
+}
+
+func LookDelta(id int) *ImageDelta {
+	return defaultSession.LookDelta(id)
 }
 
 func Feel(id int) []*Touch {
-	return currentWorld.Feel(id)
+	return defaultSession.Feel(id)
 }
 
 func Cmd(args ...any) {
-    currentWorld.Cmd(args...)
+	defaultSession.Cmd(args...)
+}
+
+func TryCmd(args ...any) error {
+	return defaultSession.TryCmd(args...)
 }